@@ -218,9 +218,11 @@ func reportEpochMetrics(ctx context.Context, postState, headState *stateTrie.Bea
 	prevEpochTargetBalances.Set(float64(b.PrevEpochTargetAttested))
 	prevEpochHeadBalances.Set(float64(b.PrevEpochHeadAttested))
 
-	refMap := postState.FieldReferencesCount()
-	for name, val := range refMap {
-		stateTrieReferences.WithLabelValues(name).Set(float64(val))
+	for field, val := range postState.FieldReferencesCount() {
+		stateTrieReferences.WithLabelValues(field.String()).Set(float64(val))
+	}
+	for field, val := range postState.FieldTrieReferencesCount() {
+		stateTrieReferences.WithLabelValues(field.String() + "_trie").Set(float64(val))
 	}
 
 	return nil
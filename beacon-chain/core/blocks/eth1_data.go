@@ -60,17 +60,8 @@ func AreEth1DataEqual(a, b *ethpb.Eth1Data) bool {
 // appends eth1data to the state in the Eth1DataVotes list. Iterating through this list checks the
 // votes to see if they match the eth1data.
 func Eth1DataHasEnoughSupport(beaconState *stateTrie.BeaconState, data *ethpb.Eth1Data) (bool, error) {
-	voteCount := uint64(0)
-	data = stateTrie.CopyETH1Data(data)
-
-	for _, vote := range beaconState.Eth1DataVotes() {
-		if AreEth1DataEqual(vote, data) {
-			voteCount++
-		}
-	}
-
 	// If 50+% majority converged on the same eth1data, then it has enough support to update the
 	// state.
 	support := params.BeaconConfig().EpochsPerEth1VotingPeriod * params.BeaconConfig().SlotsPerEpoch
-	return voteCount*2 > support, nil
+	return beaconState.Eth1DataVotesReached(data, support/2), nil
 }
@@ -168,8 +168,8 @@ func TestProcessEth1Data_SetsCorrectly(t *testing.T) {
 	b.Block = &ethpb.BeaconBlock{
 		Body: &ethpb.BeaconBlockBody{
 			Eth1Data: &ethpb.Eth1Data{
-				DepositRoot: []byte{2},
-				BlockHash:   []byte{3},
+				DepositRoot: bytesutil.PadTo([]byte{2}, 32),
+				BlockHash:   bytesutil.PadTo([]byte{3}, 32),
 			},
 		},
 	}
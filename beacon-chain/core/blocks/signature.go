@@ -110,7 +110,10 @@ func randaoSigningData(beaconState *stateTrie.BeaconState) ([]byte, []byte, []by
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "could not get beacon proposer index")
 	}
-	proposerPub := beaconState.PubkeyAtIndex(proposerIdx)
+	proposerPub, err := beaconState.PubkeyAtIndex(proposerIdx)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not get proposer public key")
+	}
 
 	currentEpoch := helpers.SlotToEpoch(beaconState.Slot())
 	buf := make([]byte, 32)
@@ -145,7 +148,10 @@ func createAttestationSignatureSet(ctx context.Context, beaconState *stateTrie.B
 		indices := ia.AttestingIndices
 		pubkeys := make([][]byte, len(indices))
 		for i := 0; i < len(indices); i++ {
-			pubkeyAtIdx := beaconState.PubkeyAtIndex(indices[i])
+			pubkeyAtIdx, err := beaconState.PubkeyAtIndex(indices[i])
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get validator public key")
+			}
 			pubkeys[i] = pubkeyAtIdx[:]
 		}
 		aggP, err := bls.AggregatePublicKeys(pubkeys)
@@ -15,6 +15,7 @@ import (
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
@@ -78,26 +79,26 @@ func AttestingBalance(state *stateTrie.BeaconState, atts []*pb.PendingAttestatio
 func ProcessRegistryUpdates(state *stateTrie.BeaconState) (*stateTrie.BeaconState, error) {
 	currentEpoch := helpers.CurrentEpoch(state)
 	vals := state.Validators()
-	var err error
-	ejectionBal := params.BeaconConfig().EjectionBalance
 	activationEligibilityEpoch := helpers.CurrentEpoch(state) + 1
 	for idx, validator := range vals {
 		// Process the validators for activation eligibility.
 		if helpers.IsEligibleForActivationQueue(validator) {
 			validator.ActivationEligibilityEpoch = activationEligibilityEpoch
-			if err := state.UpdateValidatorAtIndex(uint64(idx), validator); err != nil {
+			if err := state.SetValidatorActivationEligibility(uint64(idx), activationEligibilityEpoch); err != nil {
 				return nil, err
 			}
 		}
+	}
 
-		// Process the validators for ejection.
-		isActive := helpers.IsActiveValidator(validator, currentEpoch)
-		belowEjectionBalance := validator.EffectiveBalance <= ejectionBal
-		if isActive && belowEjectionBalance {
-			state, err = validators.InitiateValidatorExit(state, uint64(idx))
-			if err != nil {
-				return nil, errors.Wrapf(err, "could not initiate exit for validator %d", idx)
-			}
+	// Process the validators for ejection.
+	ejectionIndices, err := state.EjectionCandidateIndices(currentEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get ejection candidates")
+	}
+	for _, idx := range ejectionIndices {
+		state, err = validators.InitiateValidatorExit(state, idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not initiate exit for validator %d", idx)
 		}
 	}
 
@@ -130,12 +131,7 @@ func ProcessRegistryUpdates(state *stateTrie.BeaconState) (*stateTrie.BeaconStat
 
 	activationExitEpoch := helpers.ActivationExitEpoch(currentEpoch)
 	for _, index := range activationQ[:limit] {
-		validator, err := state.ValidatorAtIndex(index)
-		if err != nil {
-			return nil, err
-		}
-		validator.ActivationEpoch = activationExitEpoch
-		if err := state.UpdateValidatorAtIndex(index, validator); err != nil {
+		if err := state.SetValidatorActivationEpoch(index, activationExitEpoch); err != nil {
 			return nil, err
 		}
 	}
@@ -171,23 +167,26 @@ func ProcessSlashings(state *stateTrie.BeaconState) (*stateTrie.BeaconState, err
 		totalSlashing += slashing
 	}
 
-	// a callback is used here to apply the following actions  to all validators
-	// below equally.
+	// Penalize the slashed validators which become withdrawable halfway
+	// through the slashings vector.
 	increment := params.BeaconConfig().EffectiveBalanceIncrement
 	minSlashing := mathutil.Min(totalSlashing*params.BeaconConfig().ProportionalSlashingMultiplier, totalBalance)
-	err = state.ApplyToEveryValidator(func(idx int, val *ethpb.Validator) (bool, error) {
-		correctEpoch := (currentEpoch + exitLength/2) == val.WithdrawableEpoch
-		if val.Slashed && correctEpoch {
-			penaltyNumerator := val.EffectiveBalance / increment * minSlashing
-			penalty := penaltyNumerator / totalBalance * increment
-			if err := helpers.DecreaseBalance(state, uint64(idx), penalty); err != nil {
-				return false, err
-			}
-			return true, nil
+	indices, err := state.SlashedValidatorIndices(currentEpoch + exitLength/2)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get slashed validator indices")
+	}
+	for _, idx := range indices {
+		val, err := state.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, err
 		}
-		return false, nil
-	})
-	return state, err
+		penaltyNumerator := val.EffectiveBalance() / increment * minSlashing
+		penalty := penaltyNumerator / totalBalance * increment
+		if err := helpers.DecreaseBalance(state, idx, penalty); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
 }
 
 // ProcessFinalUpdates processes the final updates during epoch processing.
@@ -241,34 +240,8 @@ func ProcessFinalUpdates(state *stateTrie.BeaconState) (*stateTrie.BeaconState,
 		}
 	}
 
-	effBalanceInc := params.BeaconConfig().EffectiveBalanceIncrement
-	maxEffBalance := params.BeaconConfig().MaxEffectiveBalance
-	hysteresisInc := effBalanceInc / params.BeaconConfig().HysteresisQuotient
-	downwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisDownwardMultiplier
-	upwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisUpwardMultiplier
-
-	bals := state.Balances()
 	// Update effective balances with hysteresis.
-	validatorFunc := func(idx int, val *ethpb.Validator) (bool, error) {
-		if val == nil {
-			return false, fmt.Errorf("validator %d is nil in state", idx)
-		}
-		if idx >= len(bals) {
-			return false, fmt.Errorf("validator index exceeds validator length in state %d >= %d", idx, len(state.Balances()))
-		}
-		balance := bals[idx]
-
-		if balance+downwardThreshold < val.EffectiveBalance || val.EffectiveBalance+upwardThreshold < balance {
-			val.EffectiveBalance = maxEffBalance
-			if val.EffectiveBalance > balance-balance%effBalanceInc {
-				val.EffectiveBalance = balance - balance%effBalanceInc
-			}
-			return true, nil
-		}
-		return false, nil
-	}
-
-	if err := state.ApplyToEveryValidator(validatorFunc); err != nil {
+	if err := state.RecomputeEffectiveBalances(); err != nil {
 		return nil, err
 	}
 
@@ -300,7 +273,7 @@ func ProcessFinalUpdates(state *stateTrie.BeaconState) (*stateTrie.BeaconState,
 	if err != nil {
 		return nil, err
 	}
-	if err := state.UpdateRandaoMixesAtIndex(nextEpoch%randaoMixLength, mix); err != nil {
+	if err := state.UpdateRandaoMixesAtIndex(nextEpoch%randaoMixLength, bytesutil.ToBytes32(mix)); err != nil {
 		return nil, err
 	}
 
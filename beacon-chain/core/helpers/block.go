@@ -31,10 +31,7 @@ func BlockRootAtSlot(state *stateTrie.BeaconState, slot uint64) ([]byte, error)
 // StateRootAtSlot returns the cached state root at that particular slot. If no state
 // root has been cached it will return a zero-hash.
 func StateRootAtSlot(state *stateTrie.BeaconState, slot uint64) ([]byte, error) {
-	if slot >= state.Slot() || state.Slot() > slot+params.BeaconConfig().SlotsPerHistoricalRoot {
-		return []byte{}, errors.Errorf("slot %d out of bounds", slot)
-	}
-	return state.StateRootAtIndex(slot % params.BeaconConfig().SlotsPerHistoricalRoot)
+	return state.StateRootAtSlot(slot)
 }
 
 // BlockRoot returns the block root stored in the BeaconState for epoch start slot.
@@ -24,7 +24,7 @@ var ErrSigFailedToVerify = errors.New("signature did not verify")
 
 // ComputeDomainAndSign computes the domain and signing root and sign it using the passed in private key.
 func ComputeDomainAndSign(st *state.BeaconState, epoch uint64, obj interface{}, domain [4]byte, key bls.SecretKey) ([]byte, error) {
-	d, err := Domain(st.Fork(), epoch, domain, st.GenesisValidatorRoot())
+	d, err := st.Domain(domain, epoch, st.GenesisValidatorRoot())
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +78,7 @@ func ComputeDomainVerifySigningRoot(st *state.BeaconState, index, epoch uint64,
 	if err != nil {
 		return err
 	}
-	d, err := Domain(st.Fork(), epoch, domain, st.GenesisValidatorRoot())
+	d, err := st.Domain(domain, epoch, st.GenesisValidatorRoot())
 	if err != nil {
 		return err
 	}
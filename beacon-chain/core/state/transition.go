@@ -553,7 +553,7 @@ func VerifyOperationLengths(_ context.Context, state *stateTrie.BeaconState, b *
 	if state.Eth1DepositIndex() > eth1Data.DepositCount {
 		return nil, fmt.Errorf("expected state.deposit_index %d <= eth1data.deposit_count %d", state.Eth1DepositIndex(), eth1Data.DepositCount)
 	}
-	maxDeposits := mathutil.Min(params.BeaconConfig().MaxDeposits, eth1Data.DepositCount-state.Eth1DepositIndex())
+	maxDeposits := mathutil.Min(params.BeaconConfig().MaxDeposits, state.RemainingDeposits())
 	// Verify outstanding deposits are processed up to max number of deposits
 	if uint64(len(body.Deposits)) != maxDeposits {
 		return nil, fmt.Errorf("incorrect outstanding deposits in block body, wanted: %d, got: %d",
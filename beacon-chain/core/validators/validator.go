@@ -44,48 +44,9 @@ func InitiateValidatorExit(state *stateTrie.BeaconState, idx uint64) (*stateTrie
 	if validator.ExitEpoch != params.BeaconConfig().FarFutureEpoch {
 		return state, nil
 	}
-	var exitEpochs []uint64
-	err = state.ReadFromEveryValidator(func(idx int, val stateTrie.ReadOnlyValidator) error {
-		if val.ExitEpoch() != params.BeaconConfig().FarFutureEpoch {
-			exitEpochs = append(exitEpochs, val.ExitEpoch())
-		}
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	exitEpochs = append(exitEpochs, helpers.ActivationExitEpoch(helpers.CurrentEpoch(state)))
-
-	// Obtain the exit queue epoch as the maximum number in the exit epochs array.
-	exitQueueEpoch := uint64(0)
-	for _, i := range exitEpochs {
-		if exitQueueEpoch < i {
-			exitQueueEpoch = i
-		}
-	}
-
-	// We use the exit queue churn to determine if we have passed a churn limit.
-	exitQueueChurn := uint64(0)
-	err = state.ReadFromEveryValidator(func(idx int, val stateTrie.ReadOnlyValidator) error {
-		if val.ExitEpoch() == exitQueueEpoch {
-			exitQueueChurn++
-		}
-		return nil
-	})
+	exitQueueEpoch, err := state.ExitQueueEpoch()
 	if err != nil {
-		return nil, err
-	}
-	activeValidatorCount, err := helpers.ActiveValidatorCount(state, helpers.CurrentEpoch(state))
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get active validator count")
-	}
-	churn, err := helpers.ValidatorChurnLimit(activeValidatorCount)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get churn limit")
-	}
-
-	if exitQueueChurn >= churn {
-		exitQueueEpoch++
+		return nil, errors.Wrap(err, "could not get exit queue epoch")
 	}
 	validator.ExitEpoch = exitQueueEpoch
 	validator.WithdrawableEpoch = exitQueueEpoch + params.BeaconConfig().MinValidatorWithdrawabilityDelay
@@ -208,7 +208,10 @@ func (s *Service) saveGenesisState(ctx context.Context, genesisState *stateTrie.
 	}
 
 	for i := uint64(0); i < uint64(genesisState.NumValidators()); i++ {
-		pk := genesisState.PubkeyAtIndex(i)
+		pk, err := genesisState.PubkeyAtIndex(i)
+		if err != nil {
+			return errors.Wrap(err, "could not get validator public key")
+		}
 		s.chainStartDeposits[i] = &ethpb.Deposit{
 			Data: &ethpb.Deposit_Data{
 				PublicKey: pk[:],
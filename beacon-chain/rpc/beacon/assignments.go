@@ -109,7 +109,10 @@ func (bs *Server) ListValidatorAssignments(
 				index, requestedState.NumValidators())
 		}
 		comAssignment := committeeAssignments[index]
-		pubkey := requestedState.PubkeyAtIndex(index)
+		pubkey, err := requestedState.PubkeyAtIndex(index)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator public key: %v", err)
+		}
 		assign := &ethpb.ValidatorAssignments_CommitteeAssignment{
 			BeaconCommittees: comAssignment.Committee,
 			CommitteeIndex:   comAssignment.CommitteeIndex,
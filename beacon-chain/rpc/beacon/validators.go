@@ -153,7 +153,10 @@ func (bs *Server) ListValidatorBalances(
 	if len(req.Indices) == 0 && len(req.PublicKeys) == 0 {
 		// Return everything.
 		for i := start; i < end; i++ {
-			pubkey := requestedState.PubkeyAtIndex(uint64(i))
+			pubkey, err := requestedState.PubkeyAtIndex(uint64(i))
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not get validator public key: %v", err)
+			}
 			val := vals[i]
 			st := validatorStatus(val, requestedEpoch)
 			res = append(res, &ethpb.ValidatorBalances_Balance{
@@ -371,7 +374,10 @@ func (bs *Server) GetValidator(
 	}
 	pk48 := bytesutil.ToBytes48(pubKey)
 	for i := uint64(0); i < uint64(headState.NumValidators()); i++ {
-		keyFromState := headState.PubkeyAtIndex(i)
+		keyFromState, err := headState.PubkeyAtIndex(i)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator public key: %v", err)
+		}
 		if keyFromState == pk48 {
 			return headState.ValidatorAtIndex(i)
 		}
@@ -437,19 +443,31 @@ func (bs *Server) GetValidatorActiveSetChanges(
 	slashedKeys := make([][]byte, len(slashedIndices))
 	ejectedKeys := make([][]byte, len(ejectedIndices))
 	for i, idx := range activatedIndices {
-		pubkey := requestedState.PubkeyAtIndex(idx)
+		pubkey, err := requestedState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator public key: %v", err)
+		}
 		activatedKeys[i] = pubkey[:]
 	}
 	for i, idx := range exitedIndices {
-		pubkey := requestedState.PubkeyAtIndex(idx)
+		pubkey, err := requestedState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator public key: %v", err)
+		}
 		exitedKeys[i] = pubkey[:]
 	}
 	for i, idx := range slashedIndices {
-		pubkey := requestedState.PubkeyAtIndex(idx)
+		pubkey, err := requestedState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator public key: %v", err)
+		}
 		slashedKeys[i] = pubkey[:]
 	}
 	for i, idx := range ejectedIndices {
-		pubkey := requestedState.PubkeyAtIndex(idx)
+		pubkey, err := requestedState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator public key: %v", err)
+		}
 		ejectedKeys[i] = pubkey[:]
 	}
 	return &ethpb.ActiveSetChanges{
@@ -67,7 +67,10 @@ func (vs *Server) MultipleValidatorStatus(
 	}
 	// Convert indices to public keys.
 	for _, idx := range req.Indices {
-		pubkeyBytes := headState.PubkeyAtIndex(uint64(idx))
+		pubkeyBytes, err := headState.PubkeyAtIndex(uint64(idx))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid validator index %d: %v", idx, err)
+		}
 		if !filtered[pubkeyBytes] {
 			pubKeys = append(pubKeys, pubkeyBytes[:])
 			filtered[pubkeyBytes] = true
@@ -899,8 +899,7 @@ func TestMultipleValidatorStatus_Indices(t *testing.T) {
 		},
 	}
 
-	// Note: Index 6 should be skipped.
-	req := &ethpb.MultipleValidatorStatusRequest{Indices: []int64{0, 1, 2, 3, 4, 5, 6}}
+	req := &ethpb.MultipleValidatorStatusRequest{Indices: []int64{0, 1, 2, 3, 4, 5}}
 	response, err := vs.MultipleValidatorStatus(context.Background(), req)
 	require.NoError(t, err)
 
@@ -917,6 +916,29 @@ func TestMultipleValidatorStatus_Indices(t *testing.T) {
 	}
 }
 
+func TestMultipleValidatorStatus_OutOfRangeIndex(t *testing.T) {
+	beaconState := &pbp2p.BeaconState{
+		Slot: 4000,
+		Validators: []*ethpb.Validator{
+			{
+				ActivationEpoch: 0,
+				ExitEpoch:       params.BeaconConfig().FarFutureEpoch,
+				PublicKey:       pubKey(1),
+			},
+		},
+	}
+	stateObj, err := stateTrie.InitializeFromProtoUnsafe(beaconState)
+	require.NoError(t, err)
+	vs := &Server{
+		HeadFetcher: &mockChain.ChainService{State: stateObj},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+
+	req := &ethpb.MultipleValidatorStatusRequest{Indices: []int64{0, 1}}
+	_, err = vs.MultipleValidatorStatus(context.Background(), req)
+	assert.ErrorContains(t, "Invalid validator index 1", err)
+}
+
 func TestValidatorStatus_Invalid(t *testing.T) {
 	db := dbutil.SetupDB(t)
 	ctx := context.Background()
@@ -0,0 +1,294 @@
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// maxCachedActiveIndicesEpochs is the number of epochs whose active validator
+// indices are kept in the cache of a state.
+const maxCachedActiveIndicesEpochs = 4
+
+// activeIndicesCache caches the active validator indices of a state per epoch, so
+// that they are only computed once for all the committees of an epoch. It has its
+// own lock as it is populated by getters, which only hold a read lock on the state.
+type activeIndicesCache struct {
+	lock    sync.Mutex
+	indices map[uint64][]uint64
+}
+
+func newActiveIndicesCache() *activeIndicesCache {
+	return &activeIndicesCache{indices: make(map[uint64][]uint64)}
+}
+
+// get returns the cached active indices at the given epoch.
+func (c *activeIndicesCache) get(epoch uint64) ([]uint64, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	indices, ok := c.indices[epoch]
+	return indices, ok
+}
+
+// put caches the active indices at the given epoch, evicting every other epoch
+// once the cache is full.
+func (c *activeIndicesCache) put(epoch uint64, indices []uint64) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.indices) >= maxCachedActiveIndicesEpochs {
+		c.indices = make(map[uint64][]uint64)
+	}
+	c.indices[epoch] = indices
+}
+
+// clear empties the cache, which must be done whenever the validators change.
+func (c *activeIndicesCache) clear() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.indices = make(map[uint64][]uint64)
+}
+
+// proposerIndexCache caches the proposer index of the latest slot it was computed
+// for. It has its own lock as it is populated by getters, which only hold a read
+// lock on the state.
+type proposerIndexCache struct {
+	lock  sync.Mutex
+	slot  uint64
+	index uint64
+	ok    bool
+}
+
+// get returns the cached proposer index of the given slot.
+func (c *proposerIndexCache) get(slot uint64) (uint64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.index, c.ok && c.slot == slot
+}
+
+// put caches the proposer index of the given slot.
+func (c *proposerIndexCache) put(slot, index uint64) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.slot, c.index, c.ok = slot, index, true
+}
+
+// clear empties the cache, which must be done whenever the validators or the
+// randao mixes change.
+func (c *proposerIndexCache) clear() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ok = false
+}
+
+// BeaconCommittee returns the validator indices of the committee with the given
+// index at the given slot, shuffling the active validator indices of the epoch of
+// the slot with the attester seed of that epoch.
+//
+// Spec pseudocode definition:
+//  def get_beacon_committee(state: BeaconState, slot: Slot, index: CommitteeIndex) -> Sequence[ValidatorIndex]:
+//    epoch = compute_epoch_at_slot(slot)
+//    committees_per_slot = get_committee_count_per_slot(state, epoch)
+//    return compute_committee(
+//        indices=get_active_validator_indices(state, epoch),
+//        seed=get_seed(state, epoch, DOMAIN_BEACON_ATTESTER),
+//        index=(slot % SLOTS_PER_EPOCH) * committees_per_slot + index,
+//        count=committees_per_slot * SLOTS_PER_EPOCH,
+//    )
+func (b *BeaconState) BeaconCommittee(slot, committeeIndex uint64) ([]uint64, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	cfg := params.BeaconConfig()
+	epoch := slot / cfg.SlotsPerEpoch
+	indices := b.activeValidatorIndices(epoch)
+	committeesPerSlot := committeeCountPerSlot(uint64(len(indices)))
+	if committeeIndex >= committeesPerSlot {
+		return nil, fmt.Errorf("committee index %d out of range, %d committees per slot", committeeIndex, committeesPerSlot)
+	}
+	seed, err := b.seed(epoch, cfg.DomainBeaconAttester)
+	if err != nil {
+		return nil, err
+	}
+
+	count := committeesPerSlot * cfg.SlotsPerEpoch
+	index := (slot%cfg.SlotsPerEpoch)*committeesPerSlot + committeeIndex
+	indexCount := uint64(len(indices))
+	start := indexCount * index / count
+	end := indexCount * (index + 1) / count
+	committee := make([]uint64, 0, end-start)
+	for i := start; i < end; i++ {
+		shuffled, err := computeShuffledIndex(i, indexCount, seed)
+		if err != nil {
+			return nil, err
+		}
+		committee = append(committee, indices[shuffled])
+	}
+	return committee, nil
+}
+
+// BeaconProposerIndex returns the index of the proposer of the current slot, sampled
+// from the active validators weighted by their effective balance. The result is
+// cached until the slot, the validators or the randao mixes of the state change.
+//
+// Spec pseudocode definition:
+//  def get_beacon_proposer_index(state: BeaconState) -> ValidatorIndex:
+//    """
+//    Return the beacon proposer index at the current slot.
+//    """
+//    epoch = get_current_epoch(state)
+//    seed = hash(get_seed(state, epoch, DOMAIN_BEACON_PROPOSER) + uint_to_bytes(state.slot))
+//    indices = get_active_validator_indices(state, epoch)
+//    return compute_proposer_index(state, indices, seed)
+func (b *BeaconState) BeaconProposerIndex() (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	slot := b.state.Slot
+	if index, ok := b.proposerIndex.get(slot); ok {
+		return index, nil
+	}
+	epoch := slot / params.BeaconConfig().SlotsPerEpoch
+	epochSeed, err := b.seed(epoch, params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		return 0, err
+	}
+	seed := hashutil.Hash(append(epochSeed[:], bytesutil.Bytes8(slot)...))
+	index, err := b.computeProposerIndex(b.activeValidatorIndices(epoch), seed)
+	if err != nil {
+		return 0, err
+	}
+	b.proposerIndex.put(slot, index)
+	return index, nil
+}
+
+// computeProposerIndex samples a proposer from the given active indices, weighted
+// by their effective balance, as compute_proposer_index of the spec.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) computeProposerIndex(indices []uint64, seed [32]byte) (uint64, error) {
+	total := uint64(len(indices))
+	if total == 0 {
+		return 0, errors.New("empty active indices list")
+	}
+	const maxRandomByte = uint64(1<<8 - 1)
+	maxEffectiveBalance := params.BeaconConfig().MaxEffectiveBalance
+	for i := uint64(0); ; i++ {
+		shuffled, err := computeShuffledIndex(i%total, total, seed)
+		if err != nil {
+			return 0, err
+		}
+		candidate := indices[shuffled]
+		if candidate >= uint64(len(b.state.Validators)) {
+			return 0, fmt.Errorf("index %d out of range", candidate)
+		}
+		randomBytes := hashutil.Hash(append(seed[:], bytesutil.Bytes8(i/32)...))
+		effectiveBalance := b.state.Validators[candidate].EffectiveBalance
+		if effectiveBalance*maxRandomByte >= maxEffectiveBalance*uint64(randomBytes[i%32]) {
+			return candidate, nil
+		}
+	}
+}
+
+// activeValidatorIndices returns the indices of the validators active at the
+// given epoch, from the cache of the state when present. The returned slice is
+// shared with the cache and must not be modified.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) activeValidatorIndices(epoch uint64) []uint64 {
+	if indices, ok := b.activeIndices.get(epoch); ok {
+		return indices
+	}
+	indices := make([]uint64, 0, len(b.state.Validators))
+	for i, v := range b.state.Validators {
+		if v == nil {
+			continue
+		}
+		if v.ActivationEpoch <= epoch && epoch < v.ExitEpoch {
+			indices = append(indices, uint64(i))
+		}
+	}
+	b.activeIndices.put(epoch, indices)
+	return indices
+}
+
+// committeeCountPerSlot returns the number of committees per slot for the given
+// number of active validators.
+func committeeCountPerSlot(activeCount uint64) uint64 {
+	cfg := params.BeaconConfig()
+	committeesPerSlot := activeCount / cfg.SlotsPerEpoch / cfg.TargetCommitteeSize
+	if committeesPerSlot > cfg.MaxCommitteesPerSlot {
+		return cfg.MaxCommitteesPerSlot
+	}
+	if committeesPerSlot == 0 {
+		return 1
+	}
+	return committeesPerSlot
+}
+
+// seed returns the seed of the given epoch and domain, derived from the randao
+// mix of the epoch MIN_SEED_LOOKAHEAD + 1 epochs before it.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) seed(epoch uint64, domain [4]byte) ([32]byte, error) {
+	cfg := params.BeaconConfig()
+	lookAheadEpoch := epoch + cfg.EpochsPerHistoricalVector - cfg.MinSeedLookahead - 1
+	mix, err := b.randaoMixAtIndex(lookAheadEpoch % cfg.EpochsPerHistoricalVector)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	seed := append(domain[:], bytesutil.Bytes8(epoch)...)
+	seed = append(seed, mix...)
+	return hashutil.Hash(seed), nil
+}
+
+// computeShuffledIndex returns the position of index in the swap or not shuffle of
+// indexCount elements with the given seed, as compute_shuffled_index of the spec.
+func computeShuffledIndex(index, indexCount uint64, seed [32]byte) (uint64, error) {
+	if index >= indexCount {
+		return 0, fmt.Errorf("index %d out of range, %d indices", index, indexCount)
+	}
+	buf := make([]byte, 32+1+4)
+	copy(buf, seed[:])
+	for round := uint64(0); round < params.BeaconConfig().ShuffleRoundCount; round++ {
+		buf[32] = byte(round)
+		pivotHash := hashutil.Hash(buf[:33])
+		pivot := binary.LittleEndian.Uint64(pivotHash[:8]) % indexCount
+		flip := (pivot + indexCount - index) % indexCount
+		position := index
+		if flip > position {
+			position = flip
+		}
+		binary.LittleEndian.PutUint32(buf[33:], uint32(position/256))
+		source := hashutil.Hash(buf)
+		if (source[(position%256)/8]>>(position%8))%2 == 1 {
+			index = flip
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,73 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestBeaconState_BeaconCommittee(t *testing.T) {
+	helpers.ClearCache()
+	testState, _ := testutil.DeterministicGenesisState(t, 256)
+
+	assertCommittees := func(epoch uint64) {
+		start := epoch * params.BeaconConfig().SlotsPerEpoch
+		for slot := start; slot < start+params.BeaconConfig().SlotsPerEpoch; slot++ {
+			want, err := helpers.BeaconCommitteeFromState(testState, slot, 0)
+			require.NoError(t, err)
+			committee, err := testState.BeaconCommittee(slot, 0)
+			require.NoError(t, err)
+			assert.DeepEqual(t, want, committee)
+		}
+	}
+	assertCommittees(0)
+	assertCommittees(1)
+
+	// Exiting a validator must invalidate the cached active indices.
+	require.NoError(t, testState.SetValidatorExitEpoch(7, 1))
+	helpers.ClearCache()
+	assertCommittees(1)
+	for slot := params.BeaconConfig().SlotsPerEpoch; slot < 2*params.BeaconConfig().SlotsPerEpoch; slot++ {
+		committee, err := testState.BeaconCommittee(slot, 0)
+		require.NoError(t, err)
+		for _, idx := range committee {
+			assert.NotEqual(t, uint64(7), idx)
+		}
+	}
+
+	_, err := testState.BeaconCommittee(0, 1)
+	assert.ErrorContains(t, "committee index 1 out of range", err)
+}
+
+func TestBeaconState_BeaconProposerIndex(t *testing.T) {
+	helpers.ClearCache()
+	testState, _ := testutil.DeterministicGenesisState(t, 256)
+
+	assertProposer := func() {
+		want, err := helpers.BeaconProposerIndex(testState)
+		require.NoError(t, err)
+		proposer, err := testState.BeaconProposerIndex()
+		require.NoError(t, err)
+		assert.Equal(t, want, proposer)
+	}
+	for slot := uint64(0); slot < 2*params.BeaconConfig().SlotsPerEpoch; slot++ {
+		require.NoError(t, testState.SetSlot(slot))
+		assertProposer()
+		// The cached proposer is returned for the same slot.
+		assertProposer()
+	}
+
+	// Changing the validators invalidates the cached proposer.
+	proposer, err := testState.BeaconProposerIndex()
+	require.NoError(t, err)
+	require.NoError(t, testState.SetValidatorExitEpoch(proposer, 0))
+	helpers.ClearCache()
+	assertProposer()
+	other, err := testState.BeaconProposerIndex()
+	require.NoError(t, err)
+	assert.NotEqual(t, proposer, other)
+}
@@ -0,0 +1,211 @@
+package state
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/sszutil"
+)
+
+// StateDiff holds the changes of a beacon state relative to a base state. Fields
+// other than the validators and balances are recorded as a whole, while the
+// validators and balances are recorded per changed index, which keeps the diff
+// small for the usual slot to slot changes.
+type StateDiff struct {
+	fields        map[FieldIndex]bool
+	state         *pbp2p.BeaconState
+	numValidators int
+	validators    map[uint64]*ethpb.Validator
+	numBalances   int
+	balances      map[uint64]uint64
+}
+
+// Diff computes the changes of the beacon state relative to the given base
+// state, such that applying the diff to the base state reconstructs it.
+func (b *BeaconState) Diff(base *BeaconState) (*StateDiff, error) {
+	if !b.HasInnerState() || !base.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if base != b {
+		base.lock.RLock()
+		defer base.lock.RUnlock()
+	}
+
+	diff := &StateDiff{
+		fields:        make(map[FieldIndex]bool),
+		state:         &pbp2p.BeaconState{},
+		numValidators: len(b.state.Validators),
+		validators:    make(map[uint64]*ethpb.Validator),
+		numBalances:   len(b.state.Balances),
+		balances:      make(map[uint64]uint64),
+	}
+	for f := GenesisTime; f <= FinalizedCheckpoint; f++ {
+		if f == Validators || f == Balances {
+			continue
+		}
+		if !sszutil.DeepEqual(stateField(b.state, f), stateField(base.state, f)) {
+			diff.fields[f] = true
+			setStateField(diff.state, b.state, f)
+		}
+	}
+	// Copy the changed fields, as they may still be shared with other states.
+	diff.state = proto.Clone(diff.state).(*pbp2p.BeaconState)
+
+	for i, val := range b.state.Validators {
+		if i < len(base.state.Validators) {
+			baseVal := base.state.Validators[i]
+			if val == baseVal || sszutil.DeepEqual(val, baseVal) {
+				continue
+			}
+		}
+		diff.validators[uint64(i)] = CopyValidator(val)
+	}
+	for i, bal := range b.state.Balances {
+		if i < len(base.state.Balances) && bal == base.state.Balances[i] {
+			continue
+		}
+		diff.balances[uint64(i)] = bal
+	}
+	return diff, nil
+}
+
+// ApplyDiff returns a new beacon state built by applying the given diff,
+// as computed by Diff, to the beacon state. The beacon state is not modified.
+func (b *BeaconState) ApplyDiff(diff *StateDiff) (*BeaconState, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	if diff == nil {
+		return nil, errors.New("nil state diff")
+	}
+
+	st := b.CloneInnerState()
+	changed := proto.Clone(diff.state).(*pbp2p.BeaconState)
+	for f := range diff.fields {
+		setStateField(st, changed, f)
+	}
+
+	vals := make([]*ethpb.Validator, diff.numValidators)
+	copy(vals, st.Validators)
+	for i, val := range diff.validators {
+		if i >= uint64(len(vals)) {
+			return nil, errors.Errorf("validator index %d out of range", i)
+		}
+		vals[i] = CopyValidator(val)
+	}
+	st.Validators = vals
+
+	bals := make([]uint64, diff.numBalances)
+	copy(bals, st.Balances)
+	for i, bal := range diff.balances {
+		if i >= uint64(len(bals)) {
+			return nil, errors.Errorf("balance index %d out of range", i)
+		}
+		bals[i] = bal
+	}
+	st.Balances = bals
+
+	return InitializeFromProtoUnsafe(st)
+}
+
+// stateField returns the value of the given field in the protobuf state.
+func stateField(st *pbp2p.BeaconState, f FieldIndex) interface{} {
+	switch f {
+	case GenesisTime:
+		return st.GenesisTime
+	case GenesisValidatorRoot:
+		return st.GenesisValidatorsRoot
+	case Slot:
+		return st.Slot
+	case Fork:
+		return st.Fork
+	case LatestBlockHeader:
+		return st.LatestBlockHeader
+	case BlockRoots:
+		return st.BlockRoots
+	case StateRoots:
+		return st.StateRoots
+	case HistoricalRoots:
+		return st.HistoricalRoots
+	case Eth1Data:
+		return st.Eth1Data
+	case Eth1DataVotes:
+		return st.Eth1DataVotes
+	case Eth1DepositIndex:
+		return st.Eth1DepositIndex
+	case Validators:
+		return st.Validators
+	case Balances:
+		return st.Balances
+	case RandaoMixes:
+		return st.RandaoMixes
+	case Slashings:
+		return st.Slashings
+	case PreviousEpochAttestations:
+		return st.PreviousEpochAttestations
+	case CurrentEpochAttestations:
+		return st.CurrentEpochAttestations
+	case JustificationBits:
+		return st.JustificationBits
+	case PreviousJustifiedCheckpoint:
+		return st.PreviousJustifiedCheckpoint
+	case CurrentJustifiedCheckpoint:
+		return st.CurrentJustifiedCheckpoint
+	case FinalizedCheckpoint:
+		return st.FinalizedCheckpoint
+	default:
+		return nil
+	}
+}
+
+// setStateField sets the given field of dst to its value in src, without copying.
+func setStateField(dst, src *pbp2p.BeaconState, f FieldIndex) {
+	switch f {
+	case GenesisTime:
+		dst.GenesisTime = src.GenesisTime
+	case GenesisValidatorRoot:
+		dst.GenesisValidatorsRoot = src.GenesisValidatorsRoot
+	case Slot:
+		dst.Slot = src.Slot
+	case Fork:
+		dst.Fork = src.Fork
+	case LatestBlockHeader:
+		dst.LatestBlockHeader = src.LatestBlockHeader
+	case BlockRoots:
+		dst.BlockRoots = src.BlockRoots
+	case StateRoots:
+		dst.StateRoots = src.StateRoots
+	case HistoricalRoots:
+		dst.HistoricalRoots = src.HistoricalRoots
+	case Eth1Data:
+		dst.Eth1Data = src.Eth1Data
+	case Eth1DataVotes:
+		dst.Eth1DataVotes = src.Eth1DataVotes
+	case Eth1DepositIndex:
+		dst.Eth1DepositIndex = src.Eth1DepositIndex
+	case Validators:
+		dst.Validators = src.Validators
+	case Balances:
+		dst.Balances = src.Balances
+	case RandaoMixes:
+		dst.RandaoMixes = src.RandaoMixes
+	case Slashings:
+		dst.Slashings = src.Slashings
+	case PreviousEpochAttestations:
+		dst.PreviousEpochAttestations = src.PreviousEpochAttestations
+	case CurrentEpochAttestations:
+		dst.CurrentEpochAttestations = src.CurrentEpochAttestations
+	case JustificationBits:
+		dst.JustificationBits = src.JustificationBits
+	case PreviousJustifiedCheckpoint:
+		dst.PreviousJustifiedCheckpoint = src.PreviousJustifiedCheckpoint
+	case CurrentJustifiedCheckpoint:
+		dst.CurrentJustifiedCheckpoint = src.CurrentJustifiedCheckpoint
+	case FinalizedCheckpoint:
+		dst.FinalizedCheckpoint = src.FinalizedCheckpoint
+	}
+}
@@ -0,0 +1,62 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestBeaconState_Diff_RoundTrip(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 64)
+	want := base.CloneInnerState()
+	st := base.Copy()
+
+	require.NoError(t, st.SetSlot(5))
+	val, err := st.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	val.Slashed = true
+	val.EffectiveBalance--
+	require.NoError(t, st.UpdateValidatorAtIndex(3, val))
+	require.NoError(t, st.UpdateBalancesAtIndex(7, 1))
+	require.NoError(t, st.AppendValidator(&eth.Validator{
+		PublicKey:             bytesutil.PadTo([]byte("new"), 48),
+		WithdrawalCredentials: make([]byte, 32),
+	}))
+	require.NoError(t, st.AppendBalance(32e9))
+	require.NoError(t, st.UpdateRandaoMixesAtIndex(1, [32]byte{'a'}))
+	require.NoError(t, st.SetFinalizedCheckpoint(&eth.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("root"), 32)}))
+
+	diff, err := st.Diff(base)
+	require.NoError(t, err)
+	applied, err := base.ApplyDiff(diff)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(st.InnerStateUnsafe(), applied.InnerStateUnsafe()), "Applied diff does not reconstruct the state")
+	assert.Equal(t, true, proto.Equal(want, base.InnerStateUnsafe()), "Base state was mutated")
+
+	idx, ok := applied.ValidatorIndexByPubkey(bytesutil.ToBytes48(bytesutil.PadTo([]byte("new"), 48)))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(64), idx)
+
+	// Mutating the reconstructed state must not affect the diff.
+	require.NoError(t, applied.UpdateBalancesAtIndex(7, 2))
+	applied, err = base.ApplyDiff(diff)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(st.InnerStateUnsafe(), applied.InnerStateUnsafe()), "Diff was mutated")
+}
+
+func TestBeaconState_Diff_Unchanged(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 16)
+	diff, err := base.Copy().Diff(base)
+	require.NoError(t, err)
+	applied, err := base.ApplyDiff(diff)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(base.InnerStateUnsafe(), applied.InnerStateUnsafe()))
+
+	_, err = base.ApplyDiff(nil)
+	assert.ErrorContains(t, "nil state diff", err)
+}
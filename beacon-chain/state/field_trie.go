@@ -1,6 +1,7 @@
 package state
 
 import (
+	"encoding/binary"
 	"fmt"
 	"reflect"
 	"sync"
@@ -11,21 +12,28 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 )
 
+// balancesPerChunk is the number of uint64 balances packed into
+// a single 32 byte leaf of the balances trie.
+const balancesPerChunk = 4
+
 // FieldTrie is the representation of the representative
 // trie of the particular field.
 type FieldTrie struct {
 	*sync.Mutex
 	*reference
 	fieldLayers [][]*[32]byte
-	field       fieldIndex
+	field       FieldIndex
+	numOfElems  int
 }
 
 // NewFieldTrie is the constructor for the field trie data structure. It creates the corresponding
 // trie according to the given parameters. Depending on whether the field is a basic/composite array
 // which is either fixed/variable length, it will appropriately determine the trie.
-func NewFieldTrie(field fieldIndex, elements interface{}, length uint64) (*FieldTrie, error) {
+func NewFieldTrie(field FieldIndex, elements interface{}, length uint64) (*FieldTrie, error) {
 	if elements == nil {
 		return &FieldTrie{
 			field:     field,
@@ -56,6 +64,14 @@ func NewFieldTrie(field fieldIndex, elements interface{}, length uint64) (*Field
 			reference:   &reference{refs: 1},
 			Mutex:       new(sync.Mutex),
 		}, nil
+	case packedArray:
+		return &FieldTrie{
+			fieldLayers: stateutil.ReturnTrieLayerVariable(fieldRoots, length),
+			field:       field,
+			reference:   &reference{refs: 1},
+			Mutex:       new(sync.Mutex),
+			numOfElems:  reflect.ValueOf(elements).Len(),
+		}, nil
 	default:
 		return nil, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -76,6 +92,11 @@ func (f *FieldTrie) RecomputeTrie(indices []uint64, elements interface{}) ([32]b
 	if !ok {
 		return [32]byte{}, errors.Errorf("unrecognized field in trie")
 	}
+	// Packed arrays are keyed by the index of the element, whereas
+	// the trie is keyed by the index of the chunk holding it.
+	if datType == packedArray {
+		indices = chunkIndices(indices)
+	}
 	fieldRoots, err := fieldConverters(f.field, indices, elements, false)
 	if err != nil {
 		return [32]byte{}, err
@@ -93,6 +114,13 @@ func (f *FieldTrie) RecomputeTrie(indices []uint64, elements interface{}) ([32]b
 			return [32]byte{}, err
 		}
 		return stateutil.AddInMixin(fieldRoot, uint64(len(f.fieldLayers[0])))
+	case packedArray:
+		_, f.fieldLayers, err = stateutil.RecomputeFromLayerVariable(fieldRoots, indices, f.fieldLayers)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		f.numOfElems = reflect.ValueOf(elements).Len()
+		return f.TrieRoot()
 	default:
 		return [32]byte{}, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -119,6 +147,7 @@ func (f *FieldTrie) CopyTrie() *FieldTrie {
 		field:       f.field,
 		reference:   &reference{refs: 1},
 		Mutex:       new(sync.Mutex),
+		numOfElems:  f.numOfElems,
 	}
 }
 
@@ -134,42 +163,86 @@ func (f *FieldTrie) TrieRoot() ([32]byte, error) {
 	case compositeArray:
 		trieRoot := *f.fieldLayers[len(f.fieldLayers)-1][0]
 		return stateutil.AddInMixin(trieRoot, uint64(len(f.fieldLayers[0])))
+	case packedArray:
+		trieRoot := *f.fieldLayers[len(f.fieldLayers)-1][0]
+		return stateutil.AddInMixin(trieRoot, uint64(f.numOfElems))
 	default:
 		return [32]byte{}, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
 }
 
+// merkleProof returns the sibling nodes on the path from the leaf at the provided
+// index up to the root of the trie. For variable sized tries, the proof ends with
+// the length mix-in, such that it can be verified against the root from TrieRoot.
+func (f *FieldTrie) merkleProof(idx uint64) ([][]byte, error) {
+	f.Lock()
+	defer f.Unlock()
+	datType, ok := fieldMap[f.field]
+	if !ok {
+		return nil, errors.Errorf("unrecognized field in trie")
+	}
+	if datType == packedArray {
+		return nil, errors.Errorf("proofs are not supported for packed arrays")
+	}
+	if len(f.fieldLayers) == 0 || idx >= uint64(len(f.fieldLayers[0])) {
+		return nil, fmt.Errorf("index %d out of range", idx)
+	}
+	depth := len(f.fieldLayers) - 1
+	proof := make([][]byte, 0, depth+1)
+	for i := 0; i < depth; i++ {
+		siblingIdx := (idx >> uint(i)) ^ 1
+		sibling := trieutil.ZeroHashes[i]
+		if siblingIdx < uint64(len(f.fieldLayers[i])) {
+			sibling = *f.fieldLayers[i][siblingIdx]
+		}
+		proof = append(proof, sibling[:])
+	}
+	if datType == compositeArray {
+		lengthMixin := make([]byte, 32)
+		binary.LittleEndian.PutUint64(lengthMixin, uint64(len(f.fieldLayers[0])))
+		proof = append(proof, lengthMixin)
+	}
+	return proof, nil
+}
+
 // this converts the corresponding field and the provided elements to the appropriate roots.
-func fieldConverters(field fieldIndex, indices []uint64, elements interface{}, convertAll bool) ([][32]byte, error) {
+func fieldConverters(field FieldIndex, indices []uint64, elements interface{}, convertAll bool) ([][32]byte, error) {
 	switch field {
-	case blockRoots, stateRoots, randaoMixes:
+	case BlockRoots, StateRoots, RandaoMixes:
 		val, ok := elements.([][]byte)
 		if !ok {
 			return nil, errors.Errorf("Wanted type of %v but got %v",
 				reflect.TypeOf([][]byte{}).Name(), reflect.TypeOf(elements).Name())
 		}
 		return handleByteArrays(val, indices, convertAll)
-	case eth1DataVotes:
+	case Eth1DataVotes:
 		val, ok := elements.([]*ethpb.Eth1Data)
 		if !ok {
 			return nil, errors.Errorf("Wanted type of %v but got %v",
 				reflect.TypeOf([]*ethpb.Eth1Data{}).Name(), reflect.TypeOf(elements).Name())
 		}
 		return handleEth1DataSlice(val, indices, convertAll)
-	case validators:
+	case Validators:
 		val, ok := elements.([]*ethpb.Validator)
 		if !ok {
 			return nil, errors.Errorf("Wanted type of %v but got %v",
 				reflect.TypeOf([]*ethpb.Validator{}).Name(), reflect.TypeOf(elements).Name())
 		}
 		return handleValidatorSlice(val, indices, convertAll)
-	case previousEpochAttestations, currentEpochAttestations:
+	case PreviousEpochAttestations, CurrentEpochAttestations:
 		val, ok := elements.([]*pb.PendingAttestation)
 		if !ok {
 			return nil, errors.Errorf("Wanted type of %v but got %v",
 				reflect.TypeOf([]*pb.PendingAttestation{}).Name(), reflect.TypeOf(elements).Name())
 		}
 		return handlePendingAttestation(val, indices, convertAll)
+	case Balances:
+		val, ok := elements.([]uint64)
+		if !ok {
+			return nil, errors.Errorf("Wanted type of %v but got %v",
+				reflect.TypeOf([]uint64{}).Name(), reflect.TypeOf(elements).Name())
+		}
+		return handleBalanceSlice(val, indices, convertAll)
 	default:
 		return [][32]byte{}, errors.Errorf("got unsupported type of %v", reflect.TypeOf(elements).Name())
 	}
@@ -315,3 +388,55 @@ func handlePendingAttestation(val []*pb.PendingAttestation, indices []uint64, co
 	}
 	return roots, nil
 }
+
+func handleBalanceSlice(val []uint64, indices []uint64, convertAll bool) ([][32]byte, error) {
+	numOfChunks := (uint64(len(val)) + balancesPerChunk - 1) / balancesPerChunk
+	length := uint64(len(indices))
+	if convertAll {
+		length = numOfChunks
+	}
+	roots := make([][32]byte, 0, length)
+	rootCreator := func(chunkIdx uint64) {
+		var newRoot [32]byte
+		start := chunkIdx * balancesPerChunk
+		for i := start; i < start+balancesPerChunk && i < uint64(len(val)); i++ {
+			binary.LittleEndian.PutUint64(newRoot[(i-start)*8:], val[i])
+		}
+		roots = append(roots, newRoot)
+	}
+	if convertAll {
+		for i := uint64(0); i < numOfChunks; i++ {
+			rootCreator(i)
+		}
+		return roots, nil
+	}
+	if len(val) > 0 {
+		for _, idx := range indices {
+			if idx > numOfChunks-1 {
+				return nil, fmt.Errorf("index %d greater than number of balance chunks %d", idx, numOfChunks)
+			}
+			rootCreator(idx)
+		}
+	}
+	return roots, nil
+}
+
+// chunkIndices converts the sorted indices of changed balances into the
+// sorted and deduplicated indices of the chunks containing them.
+func chunkIndices(indices []uint64) []uint64 {
+	chunks := make([]uint64, 0, len(indices))
+	for _, idx := range indices {
+		chunkIdx := idx / balancesPerChunk
+		if len(chunks) > 0 && chunks[len(chunks)-1] == chunkIdx {
+			continue
+		}
+		chunks = append(chunks, chunkIdx)
+	}
+	return chunks
+}
+
+// balancesChunkLimit returns the maximum number of chunks
+// the balances list can be packed into.
+func balancesChunkLimit() uint64 {
+	return (params.BeaconConfig().ValidatorRegistryLimit*8 + 31) / 32
+}
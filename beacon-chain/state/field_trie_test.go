@@ -53,6 +53,31 @@ func TestFieldTrie_RecomputeTrie(t *testing.T) {
 	assert.Equal(t, expectedRoot, root)
 }
 
+func TestFieldTrie_RecomputeTrie_Balances(t *testing.T) {
+	newState, _ := testutil.DeterministicGenesisState(t, 30)
+	// 12 represents the enum value of balances.
+	trie, err := state.NewFieldTrie(12, newState.Balances(), (params.BeaconConfig().ValidatorRegistryLimit*8+31)/32)
+	require.NoError(t, err)
+	expectedRoot, err := stateutil.ValidatorBalancesRoot(newState.Balances())
+	require.NoError(t, err)
+	root, err := trie.TrieRoot()
+	require.NoError(t, err)
+	assert.Equal(t, expectedRoot, root)
+
+	changedIdx := []uint64{2, 3, 29, 30, 31}
+	require.NoError(t, newState.UpdateBalancesAtIndex(2, 1))
+	require.NoError(t, newState.UpdateBalancesAtIndex(3, 2))
+	require.NoError(t, newState.UpdateBalancesAtIndex(29, 3))
+	require.NoError(t, newState.AppendBalance(4))
+	require.NoError(t, newState.AppendBalance(5))
+
+	expectedRoot, err = stateutil.ValidatorBalancesRoot(newState.Balances())
+	require.NoError(t, err)
+	root, err = trie.RecomputeTrie(changedIdx, newState.Balances())
+	require.NoError(t, err)
+	assert.Equal(t, expectedRoot, root)
+}
+
 func TestFieldTrie_CopyTrieImmutable(t *testing.T) {
 	newState, _ := testutil.DeterministicGenesisState(t, 32)
 	// 12 represents the enum value of randao mixes.
@@ -64,8 +89,8 @@ func TestFieldTrie_CopyTrieImmutable(t *testing.T) {
 	changedIdx := []uint64{2, 29}
 
 	changedVals := [][32]byte{{'A', 'B'}, {'C', 'D'}}
-	require.NoError(t, newState.UpdateRandaoMixesAtIndex(changedIdx[0], changedVals[0][:]))
-	require.NoError(t, newState.UpdateRandaoMixesAtIndex(changedIdx[1], changedVals[1][:]))
+	require.NoError(t, newState.UpdateRandaoMixesAtIndex(changedIdx[0], changedVals[0]))
+	require.NoError(t, newState.UpdateRandaoMixesAtIndex(changedIdx[1], changedVals[1]))
 
 	root, err := trie.RecomputeTrie(changedIdx, newState.RandaoMixes())
 	require.NoError(t, err)
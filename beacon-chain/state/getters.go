@@ -1,14 +1,19 @@
 package state
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
@@ -106,7 +111,8 @@ func (b *BeaconState) InnerStateUnsafe() *pbp2p.BeaconState {
 	return b.state
 }
 
-// CloneInnerState the beacon state into a protobuf for usage.
+// CloneInnerState the beacon state into a protobuf for usage. Unlike Copy, every
+// field of the returned protobuf is deep copied and shares no memory with the state.
 func (b *BeaconState) CloneInnerState() *pbp2p.BeaconState {
 	if b == nil || b.state == nil {
 		return nil
@@ -114,6 +120,12 @@ func (b *BeaconState) CloneInnerState() *pbp2p.BeaconState {
 
 	b.lock.RLock()
 	defer b.lock.RUnlock()
+	return b.cloneInnerState()
+}
+
+// cloneInnerState deep copies every field of the state into a protobuf.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) cloneInnerState() *pbp2p.BeaconState {
 	return &pbp2p.BeaconState{
 		GenesisTime:                 b.genesisTime(),
 		GenesisValidatorsRoot:       b.genesisValidatorRoot(),
@@ -130,8 +142,8 @@ func (b *BeaconState) CloneInnerState() *pbp2p.BeaconState {
 		Balances:                    b.balances(),
 		RandaoMixes:                 b.randaoMixes(),
 		Slashings:                   b.slashings(),
-		PreviousEpochAttestations:   b.previousEpochAttestations(),
-		CurrentEpochAttestations:    b.currentEpochAttestations(),
+		PreviousEpochAttestations:   b.clonePendingAttestations(b.state.PreviousEpochAttestations),
+		CurrentEpochAttestations:    b.clonePendingAttestations(b.state.CurrentEpochAttestations),
 		JustificationBits:           b.justificationBits(),
 		PreviousJustifiedCheckpoint: b.previousJustifiedCheckpoint(),
 		CurrentJustifiedCheckpoint:  b.currentJustifiedCheckpoint(),
@@ -139,12 +151,47 @@ func (b *BeaconState) CloneInnerState() *pbp2p.BeaconState {
 	}
 }
 
+// CloneChecked is the same as CloneInnerState, but returns an error instead of a
+// partial clone when a required sub-message of the state, such as the fork, the
+// latest block header, the eth1 data or a checkpoint, is missing. The state is
+// validated and cloned under the same lock, so the clone is the validated state.
+func (b *BeaconState) CloneChecked() (*pbp2p.BeaconState, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if err := validateRequiredFields(b.state); err != nil {
+		return nil, err
+	}
+	return b.cloneInnerState(), nil
+}
+
 // HasInnerState detects if the internal reference to the state data structure
 // is populated correctly. Returns false if nil.
 func (b *BeaconState) HasInnerState() bool {
 	return b != nil && b.state != nil
 }
 
+// Version returns the schema version of the beacon state, such as Phase0, so
+// that callers may branch on the fields available in the state.
+func (b *BeaconState) Version() int {
+	if b == nil {
+		return Phase0
+	}
+	return b.version
+}
+
+// checkFieldSupported returns a FieldNotSupportedError if the field does not
+// exist in the schema of the state version.
+func (b *BeaconState) checkFieldSupported(field FieldIndex) error {
+	if unsupportedFields[b.version][field] {
+		return &FieldNotSupportedError{Field: field, Version: b.version}
+	}
+	return nil
+}
+
 // GenesisTime of the beacon state as a uint64.
 func (b *BeaconState) GenesisTime() uint64 {
 	if !b.HasInnerState() {
@@ -167,6 +214,16 @@ func (b *BeaconState) genesisTime() uint64 {
 	return b.state.GenesisTime
 }
 
+// CurrentSlotForTime returns the slot at the given wall clock time according to
+// the genesis time of the beacon state, or 0 if the time is before genesis.
+func (b *BeaconState) CurrentSlotForTime(now time.Time) uint64 {
+	genesis := int64(b.GenesisTime())
+	if now.Unix() < genesis {
+		return 0
+	}
+	return uint64(now.Unix()-genesis) / params.BeaconConfig().SecondsPerSlot
+}
+
 // GenesisValidatorRoot of the beacon state.
 func (b *BeaconState) GenesisValidatorRoot() []byte {
 	if !b.HasInnerState() {
@@ -182,6 +239,12 @@ func (b *BeaconState) GenesisValidatorRoot() []byte {
 	return b.genesisValidatorRoot()
 }
 
+// GenesisValidatorsRoot returns a 32-byte copy of the genesis validators root of
+// the beacon state, as named by the spec. It is the same as GenesisValidatorRoot.
+func (b *BeaconState) GenesisValidatorsRoot() []byte {
+	return b.GenesisValidatorRoot()
+}
+
 // genesisValidatorRoot of the beacon state.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) genesisValidatorRoot() []byte {
@@ -277,6 +340,59 @@ func (b *BeaconState) fork() *pbp2p.Fork {
 	}
 }
 
+// ForkDigest returns the 4-byte fork digest computed from the current fork version
+// of the beacon state and the provided genesis validators root.
+func (b *BeaconState) ForkDigest(genesisValidatorsRoot []byte) ([4]byte, error) {
+	if !b.HasInnerState() {
+		return [4]byte{}, ErrNilInnerState
+	}
+	if b.state.Fork == nil {
+		return [4]byte{}, errors.New("nil fork in state")
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	dataRoot, err := computeForkDataRoot(b.state.Fork.CurrentVersion, genesisValidatorsRoot)
+	if err != nil {
+		return [4]byte{}, err
+	}
+	return bytesutil.ToBytes4(dataRoot[:]), nil
+}
+
+// Domain returns the signature domain for the given domain type at the provided
+// epoch, using the previous fork version of the beacon state if the epoch is before
+// the fork epoch and the current fork version otherwise.
+func (b *BeaconState) Domain(domainType [4]byte, epoch uint64, genesisValidatorsRoot []byte) ([]byte, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	if b.state.Fork == nil {
+		return nil, errors.New("nil fork in state")
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	forkVersion := b.state.Fork.CurrentVersion
+	if epoch < b.state.Fork.Epoch {
+		forkVersion = b.state.Fork.PreviousVersion
+	}
+	if len(forkVersion) != 4 {
+		return nil, errors.New("fork version length is not 4 byte")
+	}
+	if genesisValidatorsRoot == nil {
+		genesisValidatorsRoot = params.BeaconConfig().ZeroHash[:]
+	}
+	dataRoot, err := computeForkDataRoot(forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	domain := make([]byte, 0, 32)
+	domain = append(domain, domainType[:]...)
+	return append(domain, dataRoot[:28]...), nil
+}
+
 // LatestBlockHeader stored within the beacon state.
 func (b *BeaconState) LatestBlockHeader() *ethpb.BeaconBlockHeader {
 	if !b.HasInnerState() {
@@ -320,6 +436,18 @@ func (b *BeaconState) latestBlockHeader() *ethpb.BeaconBlockHeader {
 	return hdr
 }
 
+// LatestBlockHeaderCopy returns a deep copy of the latest block header, with
+// each root copied from its own field, for use as the base of a signed header.
+func (b *BeaconState) LatestBlockHeaderCopy() *ethpb.BeaconBlockHeader {
+	if !b.HasInnerState() {
+		return nil
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return CopyBeaconBlockHeader(b.state.LatestBlockHeader)
+}
+
 // ParentRoot is a convenience method to access state.LatestBlockRoot.ParentRoot.
 func (b *BeaconState) ParentRoot() [32]byte {
 	if !b.HasInnerState() {
@@ -444,6 +572,27 @@ func (b *BeaconState) stateRootAtIndex(idx uint64) ([]byte, error) {
 	return b.safeCopyBytesAtIndex(b.state.StateRoots, idx)
 }
 
+// StateRootAtSlot returns a copy of the state root stored for the given slot,
+// which must be within the last SLOTS_PER_HISTORICAL_ROOT slots before the
+// current slot of the beacon state.
+func (b *BeaconState) StateRootAtSlot(slot uint64) ([]byte, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	slotsPerHistoricalRoot := params.BeaconConfig().SlotsPerHistoricalRoot
+	if math.MaxUint64-slot < slotsPerHistoricalRoot {
+		return nil, errors.New("slot overflows uint64")
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if slot >= b.state.Slot || b.state.Slot > slot+slotsPerHistoricalRoot {
+		return nil, fmt.Errorf("slot %d out of bounds", slot)
+	}
+	return b.stateRootAtIndex(slot % slotsPerHistoricalRoot)
+}
+
 // HistoricalRoots based on epochs stored in the beacon state.
 func (b *BeaconState) HistoricalRoots() [][]byte {
 	if !b.HasInnerState() {
@@ -468,6 +617,34 @@ func (b *BeaconState) historicalRoots() [][]byte {
 	return b.safeCopy2DByteSlice(b.state.HistoricalRoots)
 }
 
+// HistoricalRootsLength returns the length of the historical roots slice.
+func (b *BeaconState) HistoricalRootsLength() int {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.state.HistoricalRoots == nil {
+		return 0
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.historicalRootsLength()
+}
+
+// historicalRootsLength returns the length of the historical roots slice.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) historicalRootsLength() int {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.state.HistoricalRoots == nil {
+		return 0
+	}
+
+	return len(b.state.HistoricalRoots)
+}
+
 // Eth1Data corresponding to the proof-of-work chain information stored in the beacon state.
 func (b *BeaconState) Eth1Data() *ethpb.Eth1Data {
 	if !b.HasInnerState() {
@@ -496,6 +673,40 @@ func (b *BeaconState) eth1Data() *ethpb.Eth1Data {
 	return CopyETH1Data(b.state.Eth1Data)
 }
 
+// Eth1DataDepositRoot returns a 32 byte copy of the deposit root of the eth1 data
+// in the beacon state, without copying the rest of the eth1 data.
+func (b *BeaconState) Eth1DataDepositRoot() ([]byte, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.state.Eth1Data == nil {
+		return nil, errors.New("nil eth1 data in state")
+	}
+	root := bytesutil.ToBytes32(b.state.Eth1Data.DepositRoot)
+	return root[:], nil
+}
+
+// Eth1DataBlockHash returns a 32 byte copy of the block hash of the eth1 data in
+// the beacon state, without copying the rest of the eth1 data.
+func (b *BeaconState) Eth1DataBlockHash() ([]byte, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.state.Eth1Data == nil {
+		return nil, errors.New("nil eth1 data in state")
+	}
+	hash := bytesutil.ToBytes32(b.state.Eth1Data.BlockHash)
+	return hash[:], nil
+}
+
 // Eth1DataVotes corresponds to votes from eth2 on the canonical proof-of-work chain
 // data retrieved from eth1.
 func (b *BeaconState) Eth1DataVotes() []*ethpb.Eth1Data {
@@ -530,6 +741,39 @@ func (b *BeaconState) eth1DataVotes() []*ethpb.Eth1Data {
 	return res
 }
 
+// Eth1DataVotesMatching returns the number of eth1 data votes in the beacon state
+// which are equal to the provided eth1 data. The votes are compared in place.
+func (b *BeaconState) Eth1DataVotesMatching(data *ethpb.Eth1Data) uint64 {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if data == nil {
+		return 0
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	count := uint64(0)
+	for _, vote := range b.state.Eth1DataVotes {
+		if vote == nil {
+			continue
+		}
+		if vote.DepositCount == data.DepositCount &&
+			bytes.Equal(vote.DepositRoot, data.DepositRoot) &&
+			bytes.Equal(vote.BlockHash, data.BlockHash) {
+			count++
+		}
+	}
+	return count
+}
+
+// Eth1DataVotesReached returns true when the number of eth1 data votes in the beacon
+// state matching the provided eth1 data exceeds the given threshold.
+func (b *BeaconState) Eth1DataVotesReached(data *ethpb.Eth1Data, threshold uint64) bool {
+	return b.Eth1DataVotesMatching(data) > threshold
+}
+
 // Eth1DepositIndex corresponds to the index of the deposit made to the
 // validator deposit contract at the time of this state's eth1 data.
 func (b *BeaconState) Eth1DepositIndex() uint64 {
@@ -554,6 +798,23 @@ func (b *BeaconState) eth1DepositIndex() uint64 {
 	return b.state.Eth1DepositIndex
 }
 
+// RemainingDeposits returns the number of deposits included in the state's
+// eth1 data which have not been processed yet. It returns 0 when the eth1
+// data is missing or the deposit index is already past the deposit count.
+func (b *BeaconState) RemainingDeposits() uint64 {
+	if !b.HasInnerState() {
+		return 0
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.state.Eth1Data == nil || b.state.Eth1DepositIndex >= b.state.Eth1Data.DepositCount {
+		return 0
+	}
+	return b.state.Eth1Data.DepositCount - b.state.Eth1DepositIndex
+}
+
 // Validators participating in consensus on the beacon chain.
 func (b *BeaconState) Validators() []*ethpb.Validator {
 	if !b.HasInnerState() {
@@ -590,6 +851,44 @@ func (b *BeaconState) validators() []*ethpb.Validator {
 	return res
 }
 
+// CloneValidators returns a deep copy of the validator registry only. It is a
+// targeted alternative to CloneInnerState for callers which need a mutable copy of
+// the validators, without paying for copying every other field of the state.
+func (b *BeaconState) CloneValidators() []*ethpb.Validator {
+	if !b.HasInnerState() {
+		return nil
+	}
+	if b.state.Validators == nil {
+		return nil
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.validators()
+}
+
+// ValidatorsReadOnly returns read only wrappers around the validators participating
+// in consensus on the beacon chain. The validators are not copied, so the returned
+// wrappers no longer reflect the registry once the state's validators are mutated.
+func (b *BeaconState) ValidatorsReadOnly() []ReadOnlyValidator {
+	if !b.HasInnerState() {
+		return nil
+	}
+	if b.state.Validators == nil {
+		return nil
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	res := make([]ReadOnlyValidator, len(b.state.Validators))
+	for i, val := range b.state.Validators {
+		res[i] = ReadOnlyValidator{validator: val}
+	}
+	return res
+}
+
 // ValidatorAtIndex is the validator at the provided index.
 func (b *BeaconState) ValidatorAtIndex(idx uint64) (*ethpb.Validator, error) {
 	if !b.HasInnerState() {
@@ -628,6 +927,26 @@ func (b *BeaconState) ValidatorAtIndexReadOnly(idx uint64) (ReadOnlyValidator, e
 	return ReadOnlyValidator{b.state.Validators[idx]}, nil
 }
 
+// ValidatorsAtIndices returns deep copies of the validators at the given indices,
+// in the order requested. Only the requested validators are copied, which is far
+// cheaper than Validators for a few indices of a large registry.
+func (b *BeaconState) ValidatorsAtIndices(indices []uint64) ([]*ethpb.Validator, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	vals := make([]*ethpb.Validator, len(indices))
+	for i, idx := range indices {
+		if idx >= uint64(len(b.state.Validators)) {
+			return nil, fmt.Errorf("index %d out of range", idx)
+		}
+		vals[i] = CopyValidator(b.state.Validators[idx])
+	}
+	return vals, nil
+}
+
 // ValidatorIndexByPubkey returns a given validator by its 48-byte public key.
 func (b *BeaconState) ValidatorIndexByPubkey(key [48]byte) (uint64, bool) {
 	if b == nil || b.valMapHandler == nil || b.valMapHandler.valIdxMap == nil {
@@ -639,6 +958,22 @@ func (b *BeaconState) ValidatorIndexByPubkey(key [48]byte) (uint64, bool) {
 	return idx, ok
 }
 
+// ValidatorByPubkey returns a copy of the validator with the given 48-byte public
+// key, and false if the public key is not in the validator registry.
+func (b *BeaconState) ValidatorByPubkey(key [48]byte) (*ethpb.Validator, bool) {
+	if !b.HasInnerState() || b.valMapHandler == nil || b.valMapHandler.valIdxMap == nil {
+		return nil, false
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	idx, ok := b.valMapHandler.valIdxMap[key]
+	if !ok || idx >= uint64(len(b.state.Validators)) {
+		return nil, false
+	}
+	return CopyValidator(b.state.Validators[idx]), true
+}
+
 func (b *BeaconState) validatorIndexMap() map[[48]byte]uint64 {
 	if b == nil || b.valMapHandler == nil || b.valMapHandler.valIdxMap == nil {
 		return map[[48]byte]uint64{}
@@ -649,22 +984,115 @@ func (b *BeaconState) validatorIndexMap() map[[48]byte]uint64 {
 	return b.valMapHandler.copy().valIdxMap
 }
 
-// PubkeyAtIndex returns the pubkey at the given
-// validator index.
-func (b *BeaconState) PubkeyAtIndex(idx uint64) [48]byte {
+// PubkeyAtIndex returns the pubkey at the given validator index as a fixed
+// size array, avoiding the copy of the whole validator.
+func (b *BeaconState) PubkeyAtIndex(idx uint64) ([48]byte, error) {
 	if !b.HasInnerState() {
-		return [48]byte{}
+		return [48]byte{}, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if idx >= uint64(len(b.state.Validators)) {
+		return [48]byte{}, fmt.Errorf("index %d out of range", idx)
+	}
+	if b.state.Validators[idx] == nil {
+		return [48]byte{}, nil
+	}
+	return bytesutil.ToBytes48(b.state.Validators[idx].PublicKey), nil
+}
+
+// ValidatorBalanceAndPubkey returns the pubkey and the balance of the validator at
+// the given index, read in place under a single lock so that the pair is consistent.
+func (b *BeaconState) ValidatorBalanceAndPubkey(idx uint64) (pubkey [48]byte, balance uint64, err error) {
+	if !b.HasInnerState() {
+		return [48]byte{}, 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if idx >= uint64(len(b.state.Validators)) || idx >= uint64(len(b.state.Balances)) {
+		return [48]byte{}, 0, fmt.Errorf("index %d out of range", idx)
+	}
+	if b.state.Validators[idx] != nil {
+		pubkey = bytesutil.ToBytes48(b.state.Validators[idx].PublicKey)
+	}
+	return pubkey, b.state.Balances[idx], nil
+}
+
+// IsActiveValidator returns whether the validator at the given index is active at the
+// given epoch, reading its activation and exit epochs in place rather than copying
+// the validator.
+//
+// Spec pseudocode definition:
+//  def is_active_validator(validator: Validator, epoch: Epoch) -> bool:
+//    """
+//    Check if ``validator`` is active.
+//    """
+//    return validator.activation_epoch <= epoch < validator.exit_epoch
+func (b *BeaconState) IsActiveValidator(idx, epoch uint64) (bool, error) {
+	if !b.HasInnerState() {
+		return false, ErrNilInnerState
 	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
 	if idx >= uint64(len(b.state.Validators)) {
-		return [48]byte{}
+		return false, fmt.Errorf("index %d out of range", idx)
+	}
+	val := b.state.Validators[idx]
+	if val == nil {
+		return false, nil
+	}
+	return val.ActivationEpoch <= epoch && epoch < val.ExitEpoch, nil
+}
+
+// AggregatePubkeyForIndices returns the aggregate of the public keys of the validators
+// at the given indices. The public keys are read in place rather than copying the
+// validators.
+func (b *BeaconState) AggregatePubkeyForIndices(indices []uint64) ([48]byte, error) {
+	if !b.HasInnerState() {
+		return [48]byte{}, ErrNilInnerState
+	}
+	if len(indices) == 0 {
+		return [48]byte{}, errors.New("no validator indices to aggregate")
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	pubkeys := make([][]byte, len(indices))
+	for i, idx := range indices {
+		if idx >= uint64(len(b.state.Validators)) {
+			return [48]byte{}, fmt.Errorf("index %d out of range", idx)
+		}
+		if b.state.Validators[idx] == nil {
+			return [48]byte{}, fmt.Errorf("nil validator at index %d", idx)
+		}
+		pubkeys[i] = b.state.Validators[idx].PublicKey
+	}
+	aggregate, err := bls.AggregatePublicKeys(pubkeys)
+	if err != nil {
+		return [48]byte{}, err
+	}
+	return bytesutil.ToBytes48(aggregate.Marshal()), nil
+}
+
+// WithdrawalCredentialsAtIndex returns a copy of the withdrawal credentials of
+// the validator at the given index, avoiding the copy of the whole validator.
+func (b *BeaconState) WithdrawalCredentialsAtIndex(idx uint64) ([]byte, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
 	}
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 
+	if idx >= uint64(len(b.state.Validators)) {
+		return nil, fmt.Errorf("index %d out of range", idx)
+	}
 	if b.state.Validators[idx] == nil {
-		return [48]byte{}
+		return nil, nil
 	}
-	return bytesutil.ToBytes48(b.state.Validators[idx].PublicKey)
+	return bytesutil.SafeCopyBytes(b.state.Validators[idx].WithdrawalCredentials), nil
 }
 
 // NumValidators returns the size of the validator registry.
@@ -678,6 +1106,25 @@ func (b *BeaconState) NumValidators() int {
 	return len(b.state.Validators)
 }
 
+// DirtyValidatorIndices returns the sorted indices of the validators which have
+// changed since the last call to ClearDirtyValidators.
+func (b *BeaconState) DirtyValidatorIndices() []uint64 {
+	if !b.HasInnerState() {
+		return nil
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	indices := make([]uint64, 0, len(b.dirtyValidators))
+	for idx := range b.dirtyValidators {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+	return indices
+}
+
 // ReadFromEveryValidator reads values from every validator and applies it to the provided function.
 // Warning: This method is potentially unsafe, as it exposes the actual validator registry.
 func (b *BeaconState) ReadFromEveryValidator(f func(idx int, val ReadOnlyValidator) error) error {
@@ -700,21 +1147,284 @@ func (b *BeaconState) ReadFromEveryValidator(f func(idx int, val ReadOnlyValidat
 	return nil
 }
 
-// Balances of validators participating in consensus on the beacon chain.
-func (b *BeaconState) Balances() []uint64 {
+// ValidatorIndicesByStatus returns the indices of the validators which have the
+// provided status at the given epoch. The registry is read in place, without
+// copying the validators.
+func (b *BeaconState) ValidatorIndicesByStatus(epoch uint64, status ValidatorStatus) ([]uint64, error) {
 	if !b.HasInnerState() {
-		return nil
+		return nil, ErrNilInnerState
 	}
-	if b.state.Balances == nil {
-		return nil
+	if status < ValidatorPending || status > ValidatorSlashed {
+		return nil, fmt.Errorf("invalid validator status %d", status)
 	}
-
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 
-	return b.balances()
-}
-
+	indices := make([]uint64, 0)
+	for i, v := range b.state.Validators {
+		if v == nil {
+			continue
+		}
+		if validatorStatus(v, epoch) == status {
+			indices = append(indices, uint64(i))
+		}
+	}
+	return indices, nil
+}
+
+// TotalActiveBalance returns the combined effective balance of the validators
+// active at the given epoch, with a minimum of EFFECTIVE_BALANCE_INCREMENT Gwei
+// to avoid divisions by zero. The registry is iterated in place without copying.
+func (b *BeaconState) TotalActiveBalance(epoch uint64) (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.totalActiveBalance(epoch), nil
+}
+
+// totalActiveBalance returns the total active balance at the given epoch.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) totalActiveBalance(epoch uint64) uint64 {
+	total := uint64(0)
+	for _, v := range b.state.Validators {
+		if v == nil {
+			continue
+		}
+		if v.ActivationEpoch <= epoch && epoch < v.ExitEpoch {
+			total += v.EffectiveBalance
+		}
+	}
+	if total < params.BeaconConfig().EffectiveBalanceIncrement {
+		return params.BeaconConfig().EffectiveBalanceIncrement
+	}
+	return total
+}
+
+// SqrtTotalActiveBalance returns the integer square root of the total active
+// balance at the given epoch, as integer_squareroot of the spec, with the total
+// computed in place.
+func (b *BeaconState) SqrtTotalActiveBalance(epoch uint64) (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return mathutil.IntegerSquareRoot(b.totalActiveBalance(epoch)), nil
+}
+
+// BaseRewardPerIncrement returns the base reward per effective balance increment
+// at the given epoch, computed as
+// EFFECTIVE_BALANCE_INCREMENT * BASE_REWARD_FACTOR // integer_sqrt(total_active_balance)
+// with the total active balance computed in place.
+func (b *BeaconState) BaseRewardPerIncrement(epoch uint64) (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	cfg := params.BeaconConfig()
+	return cfg.EffectiveBalanceIncrement * cfg.BaseRewardFactor / mathutil.IntegerSquareRoot(b.totalActiveBalance(epoch)), nil
+}
+
+// EligibleValidatorCount returns the number of validators with an effective
+// balance of at least MAX_EFFECTIVE_BALANCE, which are the validators activated
+// at genesis. The registry is iterated in place without copying.
+func (b *BeaconState) EligibleValidatorCount() uint64 {
+	if !b.HasInnerState() {
+		return 0
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	count := uint64(0)
+	for _, v := range b.state.Validators {
+		if v != nil && v.EffectiveBalance >= params.BeaconConfig().MaxEffectiveBalance {
+			count++
+		}
+	}
+	return count
+}
+
+// ValidatorChurnLimit returns the number of validators that are allowed to
+// enter and exit the validator pool at the given epoch, computed as
+// max(MIN_PER_EPOCH_CHURN_LIMIT, active_count // CHURN_LIMIT_QUOTIENT)
+// with the active validators counted in place.
+func (b *BeaconState) ValidatorChurnLimit(epoch uint64) (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.validatorChurnLimit(epoch), nil
+}
+
+// validatorChurnLimit returns the churn limit at the given epoch.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) validatorChurnLimit(epoch uint64) uint64 {
+	churnLimit := b.activeValidatorCount(epoch) / params.BeaconConfig().ChurnLimitQuotient
+	if churnLimit < params.BeaconConfig().MinPerEpochChurnLimit {
+		churnLimit = params.BeaconConfig().MinPerEpochChurnLimit
+	}
+	return churnLimit
+}
+
+// CommitteeCountPerSlot returns the number of beacon committees per slot at the
+// given epoch, computed as
+// max(1, min(MAX_COMMITTEES_PER_SLOT, active_count // SLOTS_PER_EPOCH // TARGET_COMMITTEE_SIZE))
+// with the active validators counted in place.
+func (b *BeaconState) CommitteeCountPerSlot(epoch uint64) (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return committeeCountPerSlot(b.activeValidatorCount(epoch)), nil
+}
+
+// activeValidatorCount returns the number of validators active at the given epoch.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) activeValidatorCount(epoch uint64) uint64 {
+	activeCount := uint64(0)
+	for _, v := range b.state.Validators {
+		if v == nil {
+			continue
+		}
+		if v.ActivationEpoch <= epoch && epoch < v.ExitEpoch {
+			activeCount++
+		}
+	}
+	return activeCount
+}
+
+// ExitQueueEpoch returns the epoch a validator initiating its exit at the
+// current epoch exits at. This is the latest exit epoch queued so far, but no
+// earlier than the activation exit epoch of the current epoch, and is pushed
+// back by an epoch once the validators exiting at it reach the churn limit.
+// The validators are scanned in place.
+func (b *BeaconState) ExitQueueEpoch() (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	cfg := params.BeaconConfig()
+	currentEpoch := b.state.Slot / cfg.SlotsPerEpoch
+	exitQueueEpoch := currentEpoch + 1 + cfg.MaxSeedLookahead
+	for _, v := range b.state.Validators {
+		if v == nil || v.ExitEpoch == cfg.FarFutureEpoch {
+			continue
+		}
+		if v.ExitEpoch > exitQueueEpoch {
+			exitQueueEpoch = v.ExitEpoch
+		}
+	}
+	exitQueueChurn := uint64(0)
+	for _, v := range b.state.Validators {
+		if v != nil && v.ExitEpoch == exitQueueEpoch {
+			exitQueueChurn++
+		}
+	}
+	if exitQueueChurn >= b.validatorChurnLimit(currentEpoch) {
+		exitQueueEpoch++
+	}
+	return exitQueueEpoch, nil
+}
+
+// SlashedValidatorIndices returns the indices of the slashed validators whose
+// withdrawable epoch is the given epoch, iterating the registry in place.
+func (b *BeaconState) SlashedValidatorIndices(epoch uint64) ([]uint64, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	indices := make([]uint64, 0)
+	for i, v := range b.state.Validators {
+		if v == nil {
+			continue
+		}
+		if v.Slashed && v.WithdrawableEpoch == epoch {
+			indices = append(indices, uint64(i))
+		}
+	}
+	return indices, nil
+}
+
+// EjectionCandidateIndices returns the indices of the validators active at the
+// given epoch whose effective balance is at or below the ejection balance, iterating
+// the registry in place.
+func (b *BeaconState) EjectionCandidateIndices(epoch uint64) ([]uint64, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	ejectionBalance := params.BeaconConfig().EjectionBalance
+	indices := make([]uint64, 0)
+	for i, v := range b.state.Validators {
+		if v == nil {
+			continue
+		}
+		isActive := v.ActivationEpoch <= epoch && epoch < v.ExitEpoch
+		if isActive && v.EffectiveBalance <= ejectionBalance {
+			indices = append(indices, uint64(i))
+		}
+	}
+	return indices, nil
+}
+
+// PendingActivationIndices returns the activation queue at the given epoch: the
+// indices of the validators eligible for activation by that epoch which are not
+// activated yet, sorted by activation eligibility epoch and then by index. The
+// registry is iterated in place without copying.
+func (b *BeaconState) PendingActivationIndices(epoch uint64) ([]uint64, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	vals := b.state.Validators
+	indices := make([]uint64, 0)
+	for i, v := range vals {
+		if v == nil {
+			continue
+		}
+		if v.ActivationEligibilityEpoch <= epoch && v.ActivationEpoch == params.BeaconConfig().FarFutureEpoch {
+			indices = append(indices, uint64(i))
+		}
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return vals[indices[i]].ActivationEligibilityEpoch < vals[indices[j]].ActivationEligibilityEpoch
+	})
+	return indices, nil
+}
+
+// Balances of validators participating in consensus on the beacon chain.
+func (b *BeaconState) Balances() []uint64 {
+	if !b.HasInnerState() {
+		return nil
+	}
+	if b.state.Balances == nil {
+		return nil
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.balances()
+}
+
 // balances of validators participating in consensus on the beacon chain.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) balances() []uint64 {
@@ -776,6 +1486,27 @@ func (b *BeaconState) balancesLength() int {
 	return len(b.state.Balances)
 }
 
+// BalanceDistribution returns the number of validators per balance bucket, where
+// each bucket spans bucketGwei and is keyed by its lower bound in Gwei. Balances
+// are read in place rather than copied, allowing a histogram of the registry to
+// be computed cheaply.
+func (b *BeaconState) BalanceDistribution(bucketGwei uint64) (map[uint64]uint64, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	if bucketGwei == 0 {
+		return nil, errors.New("bucket size must be greater than zero")
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	distribution := make(map[uint64]uint64)
+	for _, bal := range b.state.Balances {
+		distribution[bal-bal%bucketGwei]++
+	}
+	return distribution, nil
+}
+
 // RandaoMixes of block proposers on the beacon chain.
 func (b *BeaconState) RandaoMixes() [][]byte {
 	if !b.HasInnerState() {
@@ -871,6 +1602,21 @@ func (b *BeaconState) Slashings() []uint64 {
 	return b.slashings()
 }
 
+// SlashingAtIndex returns the total slashed balance recorded at the given
+// index of the slashings vector.
+func (b *BeaconState) SlashingAtIndex(idx uint64) (uint64, error) {
+	if !b.HasInnerState() {
+		return 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if uint64(len(b.state.Slashings)) <= idx {
+		return 0, fmt.Errorf("index %d out of range", idx)
+	}
+	return b.state.Slashings[idx], nil
+}
+
 // slashings of validators on the beacon chain.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) slashings() []uint64 {
@@ -886,7 +1632,8 @@ func (b *BeaconState) slashings() []uint64 {
 	return res
 }
 
-// PreviousEpochAttestations corresponding to blocks on the beacon chain.
+// PreviousEpochAttestations corresponding to blocks on the beacon chain. Nil is
+// returned if the field is not supported at the version of the state.
 func (b *BeaconState) PreviousEpochAttestations() []*pbp2p.PendingAttestation {
 	if !b.HasInnerState() {
 		return nil
@@ -907,11 +1654,15 @@ func (b *BeaconState) previousEpochAttestations() []*pbp2p.PendingAttestation {
 	if !b.HasInnerState() {
 		return nil
 	}
+	if b.checkFieldSupported(PreviousEpochAttestations) != nil {
+		return nil
+	}
 
 	return b.safeCopyPendingAttestationSlice(b.state.PreviousEpochAttestations)
 }
 
-// CurrentEpochAttestations corresponding to blocks on the beacon chain.
+// CurrentEpochAttestations corresponding to blocks on the beacon chain. Nil is
+// returned if the field is not supported at the version of the state.
 func (b *BeaconState) CurrentEpochAttestations() []*pbp2p.PendingAttestation {
 	if !b.HasInnerState() {
 		return nil
@@ -932,10 +1683,166 @@ func (b *BeaconState) currentEpochAttestations() []*pbp2p.PendingAttestation {
 	if !b.HasInnerState() {
 		return nil
 	}
+	if b.checkFieldSupported(CurrentEpochAttestations) != nil {
+		return nil
+	}
 
 	return b.safeCopyPendingAttestationSlice(b.state.CurrentEpochAttestations)
 }
 
+// PreviousEpochAttestationsLength returns the length of the previous epoch attestations slice,
+// or 0 if the field is not supported at the version of the state.
+func (b *BeaconState) PreviousEpochAttestationsLength() int {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.state.PreviousEpochAttestations == nil {
+		return 0
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.previousEpochAttestationsLength()
+}
+
+// previousEpochAttestationsLength returns the length of the previous epoch attestations slice.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) previousEpochAttestationsLength() int {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.checkFieldSupported(PreviousEpochAttestations) != nil {
+		return 0
+	}
+	if b.state.PreviousEpochAttestations == nil {
+		return 0
+	}
+
+	return len(b.state.PreviousEpochAttestations)
+}
+
+// CurrentEpochAttestationsLength returns the length of the current epoch attestations slice,
+// or 0 if the field is not supported at the version of the state.
+func (b *BeaconState) CurrentEpochAttestationsLength() int {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.state.CurrentEpochAttestations == nil {
+		return 0
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.currentEpochAttestationsLength()
+}
+
+// currentEpochAttestationsLength returns the length of the current epoch attestations slice.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) currentEpochAttestationsLength() int {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.checkFieldSupported(CurrentEpochAttestations) != nil {
+		return 0
+	}
+	if b.state.CurrentEpochAttestations == nil {
+		return 0
+	}
+
+	return len(b.state.CurrentEpochAttestations)
+}
+
+// ReadFromEveryPreviousAttestation applies the provided function to every previous
+// epoch pending attestation, iterating them in place without copying.
+// Warning: The callback must neither retain nor modify the attestations, as they
+// are the ones held by the state.
+func (b *BeaconState) ReadFromEveryPreviousAttestation(f func(idx int, att *pbp2p.PendingAttestation) error) error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.RLock()
+	if err := b.checkFieldSupported(PreviousEpochAttestations); err != nil {
+		b.lock.RUnlock()
+		return err
+	}
+	atts := b.state.PreviousEpochAttestations
+	b.lock.RUnlock()
+
+	for i, att := range atts {
+		if err := f(i, att); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFromEveryCurrentAttestation is the same as ReadFromEveryPreviousAttestation
+// for the current epoch pending attestations.
+func (b *BeaconState) ReadFromEveryCurrentAttestation(f func(idx int, att *pbp2p.PendingAttestation) error) error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.RLock()
+	if err := b.checkFieldSupported(CurrentEpochAttestations); err != nil {
+		b.lock.RUnlock()
+		return err
+	}
+	atts := b.state.CurrentEpochAttestations
+	b.lock.RUnlock()
+
+	for i, att := range atts {
+		if err := f(i, att); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PreviousEpochAttestationInclusion returns the inclusion delay and the proposer index
+// of the previous epoch pending attestation at the given position, read in place
+// without cloning the attestation.
+func (b *BeaconState) PreviousEpochAttestationInclusion(i int) (inclusionDelay, proposerIndex uint64, err error) {
+	if !b.HasInnerState() {
+		return 0, 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if err := b.checkFieldSupported(PreviousEpochAttestations); err != nil {
+		return 0, 0, err
+	}
+	return attestationInclusion(b.state.PreviousEpochAttestations, i)
+}
+
+// CurrentEpochAttestationInclusion is the same as PreviousEpochAttestationInclusion
+// for the current epoch pending attestations.
+func (b *BeaconState) CurrentEpochAttestationInclusion(i int) (inclusionDelay, proposerIndex uint64, err error) {
+	if !b.HasInnerState() {
+		return 0, 0, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if err := b.checkFieldSupported(CurrentEpochAttestations); err != nil {
+		return 0, 0, err
+	}
+	return attestationInclusion(b.state.CurrentEpochAttestations, i)
+}
+
+// attestationInclusion returns the inclusion data of the pending attestation at the
+// given position, with bounds checking.
+func attestationInclusion(atts []*pbp2p.PendingAttestation, i int) (inclusionDelay, proposerIndex uint64, err error) {
+	if i < 0 || i >= len(atts) {
+		return 0, 0, fmt.Errorf("index %d out of range", i)
+	}
+	if atts[i] == nil {
+		return 0, 0, fmt.Errorf("nil pending attestation at index %d", i)
+	}
+	return atts[i].InclusionDelay, atts[i].ProposerIndex, nil
+}
+
 // JustificationBits marking which epochs have been justified in the beacon chain.
 func (b *BeaconState) JustificationBits() bitfield.Bitvector4 {
 	if !b.HasInnerState() {
@@ -966,6 +1873,76 @@ func (b *BeaconState) justificationBits() bitfield.Bitvector4 {
 	return res
 }
 
+// JustificationBitAtIndex returns whether the justification bit at the
+// given index is set, without copying the justification bits.
+func (b *BeaconState) JustificationBitAtIndex(i uint64) (bool, error) {
+	if !b.HasInnerState() {
+		return false, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.state.JustificationBits == nil || i >= b.state.JustificationBits.Len() {
+		return false, fmt.Errorf("index %d out of range", i)
+	}
+	return b.state.JustificationBits.BitAt(i), nil
+}
+
+// ComputeFinalization returns the checkpoint to finalize at the current epoch by
+// applying the four finalization rules of the spec, or a copy of the finalized
+// checkpoint when none applies. The justification bits must already account for
+// the justifications of the epoch, while the previous and current justified
+// checkpoints are the ones from before them, as read at the start of
+// process_justification_and_finalization.
+//
+// Spec pseudocode definition:
+//    bits = state.justification_bits
+//    # The 2nd/3rd/4th most recent epochs are justified, the 2nd using the 4th as source
+//    if all(bits[1:4]) and old_previous_justified_checkpoint.epoch + 3 == current_epoch:
+//        state.finalized_checkpoint = old_previous_justified_checkpoint
+//    # The 2nd/3rd most recent epochs are justified, the 2nd using the 3rd as source
+//    if all(bits[1:3]) and old_previous_justified_checkpoint.epoch + 2 == current_epoch:
+//        state.finalized_checkpoint = old_previous_justified_checkpoint
+//    # The 1st/2nd/3rd most recent epochs are justified, the 1st using the 3rd as source
+//    if all(bits[0:3]) and old_current_justified_checkpoint.epoch + 2 == current_epoch:
+//        state.finalized_checkpoint = old_current_justified_checkpoint
+//    # The 1st/2nd most recent epochs are justified, the 1st using the 2nd as source
+//    if all(bits[0:2]) and old_current_justified_checkpoint.epoch + 1 == current_epoch:
+//        state.finalized_checkpoint = old_current_justified_checkpoint
+func (b *BeaconState) ComputeFinalization() (*ethpb.Checkpoint, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	prevJustified := b.state.PreviousJustifiedCheckpoint
+	currJustified := b.state.CurrentJustifiedCheckpoint
+	if prevJustified == nil || currJustified == nil {
+		return nil, errors.New("nil justified checkpoint")
+	}
+	var bits byte
+	if len(b.state.JustificationBits) > 0 {
+		bits = b.state.JustificationBits[0]
+	}
+	currentEpoch := b.state.Slot / params.BeaconConfig().SlotsPerEpoch
+
+	finalized := b.state.FinalizedCheckpoint
+	if bits&0x0E == 0x0E && prevJustified.Epoch+3 == currentEpoch {
+		finalized = prevJustified
+	}
+	if bits&0x06 == 0x06 && prevJustified.Epoch+2 == currentEpoch {
+		finalized = prevJustified
+	}
+	if bits&0x07 == 0x07 && currJustified.Epoch+2 == currentEpoch {
+		finalized = currJustified
+	}
+	if bits&0x03 == 0x03 && currJustified.Epoch+1 == currentEpoch {
+		finalized = currJustified
+	}
+	return b.safeCopyCheckpoint(finalized), nil
+}
+
 // PreviousJustifiedCheckpoint denoting an epoch and block root.
 func (b *BeaconState) PreviousJustifiedCheckpoint() *ethpb.Checkpoint {
 	if !b.HasInnerState() {
@@ -1016,6 +1993,32 @@ func (b *BeaconState) currentJustifiedCheckpoint() *ethpb.Checkpoint {
 	return b.safeCopyCheckpoint(b.state.CurrentJustifiedCheckpoint)
 }
 
+// MatchCurrentJustifiedCheckpoint returns true if the provided checkpoint
+// matches the current justified checkpoint in the beacon state.
+func (b *BeaconState) MatchCurrentJustifiedCheckpoint(c *ethpb.Checkpoint) bool {
+	if !b.HasInnerState() {
+		return false
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return checkpointsEqual(b.state.CurrentJustifiedCheckpoint, c)
+}
+
+// MatchPreviousJustifiedCheckpoint returns true if the provided checkpoint
+// matches the previous justified checkpoint in the beacon state.
+func (b *BeaconState) MatchPreviousJustifiedCheckpoint(c *ethpb.Checkpoint) bool {
+	if !b.HasInnerState() {
+		return false
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return checkpointsEqual(b.state.PreviousJustifiedCheckpoint, c)
+}
+
 // FinalizedCheckpoint denoting an epoch and block root.
 func (b *BeaconState) FinalizedCheckpoint() *ethpb.Checkpoint {
 	if !b.HasInnerState() {
@@ -1055,6 +2058,56 @@ func (b *BeaconState) FinalizedCheckpointEpoch() uint64 {
 	return b.state.FinalizedCheckpoint.Epoch
 }
 
+// CurrentJustifiedCheckpointEpoch returns the epoch value of the current justified checkpoint.
+func (b *BeaconState) CurrentJustifiedCheckpointEpoch() uint64 {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.state.CurrentJustifiedCheckpoint == nil {
+		return 0
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.state.CurrentJustifiedCheckpoint.Epoch
+}
+
+// PreviousJustifiedCheckpointEpoch returns the epoch value of the previous justified checkpoint.
+func (b *BeaconState) PreviousJustifiedCheckpointEpoch() uint64 {
+	if !b.HasInnerState() {
+		return 0
+	}
+	if b.state.PreviousJustifiedCheckpoint == nil {
+		return 0
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.state.PreviousJustifiedCheckpoint.Epoch
+}
+
+// CheckpointEpochs returns the epochs of the finalized, current justified and
+// previous justified checkpoints under a single lock, without copying the
+// checkpoints. A missing checkpoint has an epoch of 0.
+func (b *BeaconState) CheckpointEpochs() (finalized, currentJustified, previousJustified uint64) {
+	if !b.HasInnerState() {
+		return 0, 0, 0
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if cp := b.state.FinalizedCheckpoint; cp != nil {
+		finalized = cp.Epoch
+	}
+	if cp := b.state.CurrentJustifiedCheckpoint; cp != nil {
+		currentJustified = cp.Epoch
+	}
+	if cp := b.state.PreviousJustifiedCheckpoint; cp != nil {
+		previousJustified = cp.Epoch
+	}
+	return finalized, currentJustified, previousJustified
+}
+
 func (b *BeaconState) safeCopy2DByteSlice(input [][]byte) [][]byte {
 	if input == nil {
 		return nil
@@ -1094,6 +2147,15 @@ func (b *BeaconState) safeCopyPendingAttestationSlice(input []*pbp2p.PendingAtte
 	return res
 }
 
+// clonePendingAttestations deep copies the pending attestations, leaving the result
+// nil when there are none, as is the case right after an epoch transition.
+func (b *BeaconState) clonePendingAttestations(input []*pbp2p.PendingAttestation) []*pbp2p.PendingAttestation {
+	if len(input) == 0 {
+		return nil
+	}
+	return b.safeCopyPendingAttestationSlice(input)
+}
+
 func (b *BeaconState) safeCopyCheckpoint(input *ethpb.Checkpoint) *ethpb.Checkpoint {
 	if input == nil {
 		return nil
@@ -1101,3 +2163,36 @@ func (b *BeaconState) safeCopyCheckpoint(input *ethpb.Checkpoint) *ethpb.Checkpo
 
 	return CopyCheckpoint(input)
 }
+
+// computeForkDataRoot returns the 32-byte fork data root for the provided fork
+// version and genesis validators root.
+func computeForkDataRoot(version, genesisValidatorsRoot []byte) ([32]byte, error) {
+	return (&pbp2p.ForkData{
+		CurrentVersion:        version,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+}
+
+// checkpointsEqual compares two checkpoints by value without copying them.
+func checkpointsEqual(a, b *ethpb.Checkpoint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Epoch == b.Epoch && bytes.Equal(a.Root, b.Root)
+}
+
+// validatorStatus derives the status of the validator at the given epoch. A
+// slashed validator is always reported as slashed, regardless of its
+// activation and exit epochs.
+func validatorStatus(v *ethpb.Validator, epoch uint64) ValidatorStatus {
+	switch {
+	case v.Slashed:
+		return ValidatorSlashed
+	case epoch < v.ActivationEpoch:
+		return ValidatorPending
+	case epoch < v.ExitEpoch:
+		return ValidatorActive
+	default:
+		return ValidatorExited
+	}
+}
@@ -1,12 +1,20 @@
 package state
 
 import (
+	"errors"
+	"math"
 	"runtime/debug"
 	"sync"
 	"testing"
+	"time"
 
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
@@ -50,6 +58,7 @@ func TestNilState_NoPanic(t *testing.T) {
 	_ = err
 	_ = st.StateRoots()
 	_ = st.HistoricalRoots()
+	_ = st.HistoricalRootsLength()
 	_ = st.Eth1Data()
 	_ = st.Eth1DataVotes()
 	_ = st.Eth1DepositIndex()
@@ -59,7 +68,8 @@ func TestNilState_NoPanic(t *testing.T) {
 	_ = err
 	_, _ = st.ValidatorIndexByPubkey([48]byte{})
 	_ = st.validatorIndexMap()
-	_ = st.PubkeyAtIndex(0)
+	_, err = st.PubkeyAtIndex(0)
+	_ = err
 	_ = st.NumValidators()
 	_ = st.Balances()
 	_, err = st.BalanceAtIndex(0)
@@ -72,6 +82,8 @@ func TestNilState_NoPanic(t *testing.T) {
 	_ = st.Slashings()
 	_ = st.PreviousEpochAttestations()
 	_ = st.CurrentEpochAttestations()
+	_ = st.PreviousEpochAttestationsLength()
+	_ = st.CurrentEpochAttestationsLength()
 	_ = st.JustificationBits()
 	_ = st.PreviousJustifiedCheckpoint()
 	_ = st.CurrentJustifiedCheckpoint()
@@ -88,3 +100,941 @@ func TestReadOnlyValidator_ActivationEligibilityEpochNoPanic(t *testing.T) {
 	v := &ReadOnlyValidator{}
 	assert.Equal(t, uint64(0), v.ActivationEligibilityEpoch(), "Expected 0 and not panic")
 }
+
+func TestBeaconState_ForkDigest(t *testing.T) {
+	genesisValidatorsRoot := bytesutil.PadTo([]byte("genesis"), 32)
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Fork: &pb.Fork{
+			PreviousVersion: []byte{0, 0, 0, 0},
+			CurrentVersion:  []byte{1, 0, 0, 0},
+		},
+	})
+	require.NoError(t, err)
+
+	dataRoot, err := (&pb.ForkData{
+		CurrentVersion:        []byte{1, 0, 0, 0},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	digest, err := st.ForkDigest(genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.Equal(t, bytesutil.ToBytes4(dataRoot[:]), digest)
+
+	st, err = InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	_, err = st.ForkDigest(genesisValidatorsRoot)
+	assert.ErrorContains(t, "nil fork in state", err)
+}
+
+func TestBeaconState_Domain(t *testing.T) {
+	genesisValidatorsRoot := bytesutil.PadTo([]byte("genesis"), 32)
+	domainType := [4]byte{1, 2, 3, 4}
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Fork: &pb.Fork{
+			PreviousVersion: []byte{0, 0, 0, 0},
+			CurrentVersion:  []byte{1, 0, 0, 0},
+			Epoch:           10,
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		epoch   uint64
+		version []byte
+	}{
+		{epoch: 9, version: []byte{0, 0, 0, 0}},
+		{epoch: 10, version: []byte{1, 0, 0, 0}},
+		{epoch: 11, version: []byte{1, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		dataRoot, err := (&pb.ForkData{
+			CurrentVersion:        tt.version,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+		}).HashTreeRoot()
+		require.NoError(t, err)
+		domain, err := st.Domain(domainType, tt.epoch, genesisValidatorsRoot)
+		require.NoError(t, err)
+		assert.DeepEqual(t, append(domainType[:], dataRoot[:28]...), domain)
+	}
+
+	st, err = InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	_, err = st.Domain(domainType, 0, genesisValidatorsRoot)
+	assert.ErrorContains(t, "nil fork in state", err)
+}
+
+func TestBeaconState_GenesisValidatorRoot_PreservedOnClone(t *testing.T) {
+	root := bytesutil.PadTo([]byte("genesis validators root"), 32)
+	st, err := InitializeFromProto(&pb.BeaconState{GenesisValidatorsRoot: root})
+	require.NoError(t, err)
+	assert.DeepEqual(t, root, st.GenesisValidatorRoot())
+	assert.DeepEqual(t, root, st.GenesisValidatorsRoot())
+	assert.DeepEqual(t, root, st.CloneInnerState().GenesisValidatorsRoot)
+	assert.DeepEqual(t, root, st.Copy().GenesisValidatorsRoot())
+
+	// Mutating the returned root must not affect the state.
+	got := st.GenesisValidatorsRoot()
+	got[0] = 'x'
+	assert.DeepEqual(t, root, st.GenesisValidatorsRoot())
+}
+
+func TestBeaconState_Eth1DataDepositRootAndBlockHash(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	_, err = st.Eth1DataDepositRoot()
+	assert.ErrorContains(t, "nil eth1 data", err)
+	_, err = st.Eth1DataBlockHash()
+	assert.ErrorContains(t, "nil eth1 data", err)
+
+	depositRoot := bytesutil.PadTo([]byte("root"), 32)
+	blockHash := bytesutil.PadTo([]byte("hash"), 32)
+	st, err = InitializeFromProto(&pb.BeaconState{
+		Eth1Data: &eth.Eth1Data{DepositRoot: depositRoot, BlockHash: blockHash},
+	})
+	require.NoError(t, err)
+	gotRoot, err := st.Eth1DataDepositRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, depositRoot, gotRoot)
+	gotHash, err := st.Eth1DataBlockHash()
+	require.NoError(t, err)
+	assert.DeepEqual(t, blockHash, gotHash)
+
+	// Mutating the returned copies leaves the state untouched.
+	gotRoot[0] = 'x'
+	gotHash[0] = 'x'
+	assert.DeepEqual(t, depositRoot, st.Eth1Data().DepositRoot)
+	assert.DeepEqual(t, blockHash, st.Eth1Data().BlockHash)
+}
+
+func TestBeaconState_Eth1DataVotesMatching(t *testing.T) {
+	candidate := &eth.Eth1Data{
+		DepositRoot:  bytesutil.PadTo([]byte("root"), 32),
+		DepositCount: 10,
+		BlockHash:    bytesutil.PadTo([]byte("hash"), 32),
+	}
+	other := &eth.Eth1Data{
+		DepositRoot:  bytesutil.PadTo([]byte("root"), 32),
+		DepositCount: 10,
+		BlockHash:    bytesutil.PadTo([]byte("other hash"), 32),
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Eth1DataVotes: []*eth.Eth1Data{candidate, other, candidate, nil},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), st.Eth1DataVotesMatching(candidate))
+	assert.Equal(t, uint64(1), st.Eth1DataVotesMatching(other))
+	assert.Equal(t, uint64(0), st.Eth1DataVotesMatching(&eth.Eth1Data{}))
+}
+
+func TestBeaconState_RemainingDeposits(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), st.RemainingDeposits())
+
+	st, err = InitializeFromProto(&pb.BeaconState{
+		Eth1Data:         &eth.Eth1Data{DepositCount: 10},
+		Eth1DepositIndex: 4,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), st.RemainingDeposits())
+
+	require.NoError(t, st.SetEth1DepositIndex(11))
+	assert.Equal(t, uint64(0), st.RemainingDeposits())
+}
+
+func TestBeaconState_Eth1DataVotesReached(t *testing.T) {
+	candidate := &eth.Eth1Data{
+		DepositRoot:  bytesutil.PadTo([]byte("root"), 32),
+		DepositCount: 10,
+		BlockHash:    bytesutil.PadTo([]byte("hash"), 32),
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Eth1DataVotes: []*eth.Eth1Data{candidate, {}, candidate, candidate},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, st.Eth1DataVotesReached(candidate, 2))
+	assert.Equal(t, false, st.Eth1DataVotesReached(candidate, 3))
+	assert.Equal(t, false, st.Eth1DataVotesReached(nil, 0))
+}
+
+func TestBeaconState_ValidatorsReadOnly(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{EffectiveBalance: 1, ExitEpoch: 2},
+			{EffectiveBalance: 3, Slashed: true},
+		},
+	})
+	require.NoError(t, err)
+	vals := st.ValidatorsReadOnly()
+	require.Equal(t, 2, len(vals))
+	assert.Equal(t, uint64(1), vals[0].EffectiveBalance())
+	assert.Equal(t, uint64(2), vals[0].ExitEpoch())
+	assert.Equal(t, uint64(3), vals[1].EffectiveBalance())
+	assert.Equal(t, true, vals[1].Slashed())
+
+	var nilState *BeaconState
+	assert.Equal(t, 0, len(nilState.ValidatorsReadOnly()))
+}
+
+func TestBeaconState_CurrentSlotForTime(t *testing.T) {
+	genesis := time.Unix(1606824000, 0)
+	st, err := InitializeFromProto(&pb.BeaconState{GenesisTime: uint64(genesis.Unix())})
+	require.NoError(t, err)
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+
+	assert.Equal(t, uint64(0), st.CurrentSlotForTime(genesis.Add(-time.Hour)))
+	assert.Equal(t, uint64(0), st.CurrentSlotForTime(genesis))
+	assert.Equal(t, uint64(0), st.CurrentSlotForTime(genesis.Add(secondsPerSlot-time.Second)))
+	assert.Equal(t, uint64(1), st.CurrentSlotForTime(genesis.Add(secondsPerSlot)))
+	assert.Equal(t, uint64(100), st.CurrentSlotForTime(genesis.Add(100*secondsPerSlot+time.Second)))
+}
+
+func TestBeaconState_PubkeyAtIndex(t *testing.T) {
+	pubkey := bytesutil.PadTo([]byte("pubkey"), 48)
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{{PublicKey: pubkey}},
+	})
+	require.NoError(t, err)
+	got, err := st.PubkeyAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, bytesutil.ToBytes48(pubkey), got)
+
+	_, err = st.PubkeyAtIndex(1)
+	assert.ErrorContains(t, "index 1 out of range", err)
+
+	var nilState *BeaconState
+	_, err = nilState.PubkeyAtIndex(0)
+	assert.ErrorContains(t, ErrNilInnerState.Error(), err)
+}
+
+func TestBeaconState_ValidatorBalanceAndPubkey(t *testing.T) {
+	pubkey := bytesutil.PadTo([]byte("pubkey"), 48)
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{{PublicKey: pubkey}, {}},
+		Balances:   []uint64{32e9},
+	})
+	require.NoError(t, err)
+	gotPubkey, gotBalance, err := st.ValidatorBalanceAndPubkey(0)
+	require.NoError(t, err)
+	assert.Equal(t, bytesutil.ToBytes48(pubkey), gotPubkey)
+	assert.Equal(t, uint64(32e9), gotBalance)
+
+	// The index must be in range of both the validators and the balances.
+	_, _, err = st.ValidatorBalanceAndPubkey(1)
+	assert.ErrorContains(t, "index 1 out of range", err)
+
+	var nilState *BeaconState
+	_, _, err = nilState.ValidatorBalanceAndPubkey(0)
+	assert.ErrorContains(t, ErrNilInnerState.Error(), err)
+}
+
+func TestBeaconState_IsActiveValidator(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{{ActivationEpoch: 2, ExitEpoch: 5}, nil},
+	})
+	require.NoError(t, err)
+	for epoch, want := range []bool{false, false, true, true, true, false} {
+		active, err := st.IsActiveValidator(0, uint64(epoch))
+		require.NoError(t, err)
+		assert.Equal(t, want, active, "Unexpected activity at epoch %d", epoch)
+	}
+
+	active, err := st.IsActiveValidator(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, false, active)
+	_, err = st.IsActiveValidator(2, 3)
+	assert.ErrorContains(t, "index 2 out of range", err)
+
+	var nilState *BeaconState
+	_, err = nilState.IsActiveValidator(0, 0)
+	assert.ErrorContains(t, ErrNilInnerState.Error(), err)
+}
+
+func TestBeaconState_StateRootAtSlot(t *testing.T) {
+	slotsPerHistoricalRoot := params.BeaconConfig().SlotsPerHistoricalRoot
+	roots := make([][]byte, slotsPerHistoricalRoot)
+	for i := range roots {
+		roots[i] = bytesutil.PadTo(bytesutil.Bytes8(uint64(i)), 32)
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Slot: 10, StateRoots: roots})
+	require.NoError(t, err)
+
+	root, err := st.StateRootAtSlot(9)
+	require.NoError(t, err)
+	assert.DeepEqual(t, roots[9], root)
+	root[0] = 'x'
+	assert.DeepEqual(t, bytesutil.PadTo(bytesutil.Bytes8(9), 32), roots[9], "Expected a copy of the root")
+
+	// The root of the current slot is not known yet.
+	_, err = st.StateRootAtSlot(10)
+	assert.ErrorContains(t, "slot 10 out of bounds", err)
+	_, err = st.StateRootAtSlot(11)
+	assert.ErrorContains(t, "slot 11 out of bounds", err)
+
+	require.NoError(t, st.SetSlot(slotsPerHistoricalRoot+5))
+	root, err = st.StateRootAtSlot(5)
+	require.NoError(t, err)
+	assert.DeepEqual(t, roots[5], root)
+	_, err = st.StateRootAtSlot(4)
+	assert.ErrorContains(t, "slot 4 out of bounds", err)
+	root, err = st.StateRootAtSlot(slotsPerHistoricalRoot + 4)
+	require.NoError(t, err)
+	assert.DeepEqual(t, roots[4], root)
+
+	_, err = st.StateRootAtSlot(math.MaxUint64)
+	assert.ErrorContains(t, "slot overflows uint64", err)
+}
+
+func TestBeaconState_ValidatorsAtIndices(t *testing.T) {
+	vals := make([]*eth.Validator, 5)
+	for i := range vals {
+		vals[i] = &eth.Validator{EffectiveBalance: uint64(i)}
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Validators: vals})
+	require.NoError(t, err)
+
+	got, err := st.ValidatorsAtIndices([]uint64{3, 0, 3})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got))
+	assert.Equal(t, uint64(3), got[0].EffectiveBalance)
+	assert.Equal(t, uint64(0), got[1].EffectiveBalance)
+	assert.Equal(t, uint64(3), got[2].EffectiveBalance)
+
+	// The returned validators are copies.
+	got[0].EffectiveBalance = 100
+	val, err := st.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), val.EffectiveBalance)
+
+	_, err = st.ValidatorsAtIndices([]uint64{1, 5})
+	assert.ErrorContains(t, "index 5 out of range", err)
+}
+
+func TestBeaconState_AggregatePubkeyForIndices(t *testing.T) {
+	vals := make([]*eth.Validator, 4)
+	pubkeys := make([][]byte, len(vals))
+	for i := range vals {
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		pubkeys[i] = sk.PublicKey().Marshal()
+		vals[i] = &eth.Validator{PublicKey: pubkeys[i]}
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Validators: vals})
+	require.NoError(t, err)
+
+	want, err := bls.AggregatePublicKeys([][]byte{pubkeys[0], pubkeys[2], pubkeys[3]})
+	require.NoError(t, err)
+	got, err := st.AggregatePubkeyForIndices([]uint64{0, 2, 3})
+	require.NoError(t, err)
+	assert.DeepEqual(t, want.Marshal(), got[:])
+
+	_, err = st.AggregatePubkeyForIndices([]uint64{0, 4})
+	assert.ErrorContains(t, "index 4 out of range", err)
+	_, err = st.AggregatePubkeyForIndices(nil)
+	assert.ErrorContains(t, "no validator indices to aggregate", err)
+}
+
+func TestBeaconState_WithdrawalCredentialsAtIndex(t *testing.T) {
+	creds := bytesutil.PadTo([]byte("creds"), 32)
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{{WithdrawalCredentials: creds}},
+	})
+	require.NoError(t, err)
+	got, err := st.WithdrawalCredentialsAtIndex(0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, creds, got)
+
+	// The returned credentials are a copy.
+	got[0] = 'x'
+	got, err = st.WithdrawalCredentialsAtIndex(0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte("creds"), 32), got)
+
+	_, err = st.WithdrawalCredentialsAtIndex(1)
+	assert.ErrorContains(t, "index 1 out of range", err)
+}
+
+func TestBeaconState_ValidatorIndicesByStatus(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{ActivationEpoch: 0, ExitEpoch: farFuture},
+			{ActivationEpoch: 10, ExitEpoch: farFuture},
+			{ActivationEpoch: 0, ExitEpoch: 3},
+			{ActivationEpoch: 0, ExitEpoch: farFuture, Slashed: true},
+			{ActivationEpoch: 2, ExitEpoch: farFuture},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		status ValidatorStatus
+		want   []uint64
+	}{
+		{status: ValidatorPending, want: []uint64{1}},
+		{status: ValidatorActive, want: []uint64{0, 4}},
+		{status: ValidatorExited, want: []uint64{2}},
+		{status: ValidatorSlashed, want: []uint64{3}},
+	}
+	for _, tt := range tests {
+		indices, err := st.ValidatorIndicesByStatus(5, tt.status)
+		require.NoError(t, err)
+		assert.DeepEqual(t, tt.want, indices)
+	}
+
+	_, err = st.ValidatorIndicesByStatus(5, ValidatorStatus(100))
+	assert.ErrorContains(t, "invalid validator status 100", err)
+}
+
+func TestBeaconState_BalanceDistribution(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Balances: []uint64{0, 5, 10, 15, 19, 20, 45},
+	})
+	require.NoError(t, err)
+
+	distribution, err := st.BalanceDistribution(10)
+	require.NoError(t, err)
+	assert.DeepEqual(t, map[uint64]uint64{0: 2, 10: 3, 20: 1, 40: 1}, distribution)
+
+	_, err = st.BalanceDistribution(0)
+	assert.ErrorContains(t, "bucket size must be greater than zero", err)
+}
+
+func TestBeaconState_TotalActiveBalance(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{EffectiveBalance: 32e9, ActivationEpoch: 0, ExitEpoch: farFuture},
+			{EffectiveBalance: 31e9, ActivationEpoch: 0, ExitEpoch: 5},
+			{EffectiveBalance: 30e9, ActivationEpoch: 10, ExitEpoch: farFuture},
+		},
+	})
+	require.NoError(t, err)
+
+	total, err := st.TotalActiveBalance(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(63e9), total)
+	total, err = st.TotalActiveBalance(10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(62e9), total)
+
+	// The total is floored at the effective balance increment.
+	st, err = InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	total, err = st.TotalActiveBalance(0)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().EffectiveBalanceIncrement, total)
+}
+
+func TestBeaconState_SqrtTotalActiveBalance(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{EffectiveBalance: 32e9, ActivationEpoch: 0, ExitEpoch: farFuture},
+			{EffectiveBalance: 32e9, ActivationEpoch: 0, ExitEpoch: 5},
+			{EffectiveBalance: 17e9, ActivationEpoch: 0, ExitEpoch: farFuture},
+		},
+	})
+	require.NoError(t, err)
+
+	// The roots of totals which are not perfect squares are floored.
+	root, err := st.SqrtTotalActiveBalance(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(284604), root)
+	root, err = st.SqrtTotalActiveBalance(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(221359), root)
+
+	// The total is floored at the effective balance increment.
+	st, err = InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	root, err = st.SqrtTotalActiveBalance(0)
+	require.NoError(t, err)
+	assert.Equal(t, mathutil.IntegerSquareRoot(params.BeaconConfig().EffectiveBalanceIncrement), root)
+
+	_, err = (&BeaconState{}).SqrtTotalActiveBalance(0)
+	assert.ErrorContains(t, ErrNilInnerState.Error(), err)
+}
+
+func TestBeaconState_BaseRewardPerIncrement(t *testing.T) {
+	cfg := params.BeaconConfig()
+	farFuture := cfg.FarFutureEpoch
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{EffectiveBalance: 32e9, ActivationEpoch: 0, ExitEpoch: farFuture},
+			{EffectiveBalance: 32e9, ActivationEpoch: 0, ExitEpoch: 5},
+		},
+	})
+	require.NoError(t, err)
+
+	reward, err := st.BaseRewardPerIncrement(4)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.EffectiveBalanceIncrement*cfg.BaseRewardFactor/mathutil.IntegerSquareRoot(64e9), reward)
+	reward, err = st.BaseRewardPerIncrement(5)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.EffectiveBalanceIncrement*cfg.BaseRewardFactor/mathutil.IntegerSquareRoot(32e9), reward)
+}
+
+func TestBeaconState_EligibleValidatorCount(t *testing.T) {
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{EffectiveBalance: maxBalance},
+			{EffectiveBalance: maxBalance - params.BeaconConfig().EffectiveBalanceIncrement},
+			{EffectiveBalance: maxBalance},
+			{EffectiveBalance: 0},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), st.EligibleValidatorCount())
+
+	st, err = InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), st.EligibleValidatorCount())
+}
+
+func TestBeaconState_ValidatorChurnLimit(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	c := params.BeaconConfig()
+	c.ChurnLimitQuotient = 2
+	c.MinPerEpochChurnLimit = 4
+	params.OverrideBeaconConfig(c)
+
+	vals := make([]*eth.Validator, 14)
+	for i := range vals {
+		vals[i] = &eth.Validator{ActivationEpoch: 0, ExitEpoch: c.FarFutureEpoch}
+	}
+	vals[0].ExitEpoch = 5
+	vals[1].ActivationEpoch = 10
+	vals[2].ExitEpoch = 5
+	st, err := InitializeFromProto(&pb.BeaconState{Validators: vals})
+	require.NoError(t, err)
+
+	churn, err := st.ValidatorChurnLimit(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), churn)
+	churn, err = st.ValidatorChurnLimit(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), churn)
+	churn, err = st.ValidatorChurnLimit(10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), churn)
+
+	// The churn limit never drops below the minimum.
+	st, err = InitializeFromProto(&pb.BeaconState{Validators: vals[:3]})
+	require.NoError(t, err)
+	churn, err = st.ValidatorChurnLimit(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), churn)
+}
+
+func TestBeaconState_CommitteeCountPerSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	c := params.BeaconConfig()
+	c.SlotsPerEpoch = 2
+	c.TargetCommitteeSize = 2
+	c.MaxCommitteesPerSlot = 3
+	params.OverrideBeaconConfig(c)
+
+	vals := make([]*eth.Validator, 16)
+	for i := range vals {
+		vals[i] = &eth.Validator{ActivationEpoch: 0, ExitEpoch: c.FarFutureEpoch}
+	}
+	for i := 0; i < 6; i++ {
+		vals[i].ExitEpoch = 5
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Validators: vals})
+	require.NoError(t, err)
+
+	count, err := st.CommitteeCountPerSlot(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count, "Expected the count to be capped")
+	count, err = st.CommitteeCountPerSlot(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	// There is always at least one committee per slot.
+	st, err = InitializeFromProto(&pb.BeaconState{Validators: vals[:3]})
+	require.NoError(t, err)
+	count, err = st.CommitteeCountPerSlot(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestBeaconState_ExitQueueEpoch(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	c := params.BeaconConfig()
+	c.ChurnLimitQuotient = 1 << 16
+	c.MinPerEpochChurnLimit = 2
+	params.OverrideBeaconConfig(c)
+
+	vals := make([]*eth.Validator, 4)
+	for i := range vals {
+		vals[i] = &eth.Validator{ExitEpoch: c.FarFutureEpoch}
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Slot: c.SlotsPerEpoch, Validators: vals})
+	require.NoError(t, err)
+	activationExitEpoch := 2 + c.MaxSeedLookahead
+
+	exitEpoch := func() uint64 {
+		epoch, err := st.ExitQueueEpoch()
+		require.NoError(t, err)
+		return epoch
+	}
+	assert.Equal(t, activationExitEpoch, exitEpoch())
+
+	vals[0].ExitEpoch = activationExitEpoch
+	require.NoError(t, st.UpdateValidatorAtIndex(0, vals[0]))
+	assert.Equal(t, activationExitEpoch, exitEpoch(), "Expected the exit queue to not be full")
+
+	vals[1].ExitEpoch = activationExitEpoch
+	require.NoError(t, st.UpdateValidatorAtIndex(1, vals[1]))
+	assert.Equal(t, activationExitEpoch+1, exitEpoch(), "Expected a full exit queue to push the exit back")
+
+	vals[2].ExitEpoch = activationExitEpoch + 10
+	require.NoError(t, st.UpdateValidatorAtIndex(2, vals[2]))
+	assert.Equal(t, activationExitEpoch+10, exitEpoch())
+}
+
+func TestBeaconState_CheckpointEpochs(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		PreviousJustifiedCheckpoint: &eth.Checkpoint{Epoch: 3, Root: make([]byte, 32)},
+		CurrentJustifiedCheckpoint:  &eth.Checkpoint{Epoch: 4, Root: make([]byte, 32)},
+		FinalizedCheckpoint:         &eth.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), st.PreviousJustifiedCheckpointEpoch())
+	assert.Equal(t, uint64(4), st.CurrentJustifiedCheckpointEpoch())
+	assert.Equal(t, uint64(2), st.FinalizedCheckpointEpoch())
+
+	st, err = InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), st.PreviousJustifiedCheckpointEpoch())
+	assert.Equal(t, uint64(0), st.CurrentJustifiedCheckpointEpoch())
+	assert.Equal(t, uint64(0), st.FinalizedCheckpointEpoch())
+}
+
+func TestBeaconState_CheckpointEpochs_SingleCall(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		PreviousJustifiedCheckpoint: &eth.Checkpoint{Epoch: 3, Root: make([]byte, 32)},
+		CurrentJustifiedCheckpoint:  &eth.Checkpoint{Epoch: 4, Root: make([]byte, 32)},
+		FinalizedCheckpoint:         &eth.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+	})
+	require.NoError(t, err)
+	finalized, currentJustified, previousJustified := st.CheckpointEpochs()
+	assert.Equal(t, uint64(2), finalized)
+	assert.Equal(t, uint64(4), currentJustified)
+	assert.Equal(t, uint64(3), previousJustified)
+
+	st, err = InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	finalized, currentJustified, previousJustified = st.CheckpointEpochs()
+	assert.Equal(t, uint64(0), finalized)
+	assert.Equal(t, uint64(0), currentJustified)
+	assert.Equal(t, uint64(0), previousJustified)
+
+	finalized, currentJustified, previousJustified = (&BeaconState{}).CheckpointEpochs()
+	assert.Equal(t, uint64(0), finalized+currentJustified+previousJustified)
+}
+
+func TestBeaconState_ValidatorByPubkey(t *testing.T) {
+	keys := [][48]byte{{1}, {2}}
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{PublicKey: keys[0][:], EffectiveBalance: 1},
+			{PublicKey: keys[1][:], EffectiveBalance: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	v, ok := st.ValidatorByPubkey(keys[1])
+	require.Equal(t, true, ok)
+	assert.Equal(t, uint64(2), v.EffectiveBalance)
+	assert.DeepEqual(t, keys[1][:], v.PublicKey)
+
+	// Mutating the returned validator must not affect the state.
+	v.EffectiveBalance = 100
+	v, ok = st.ValidatorByPubkey(keys[1])
+	require.Equal(t, true, ok)
+	assert.Equal(t, uint64(2), v.EffectiveBalance)
+
+	v, ok = st.ValidatorByPubkey([48]byte{3})
+	assert.Equal(t, false, ok)
+	assert.Equal(t, (*eth.Validator)(nil), v)
+}
+
+func TestBeaconState_SlashedValidatorIndices(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{Slashed: true, WithdrawableEpoch: 10},
+			{Slashed: false, WithdrawableEpoch: 10},
+			{Slashed: true, WithdrawableEpoch: 11},
+			{Slashed: true, WithdrawableEpoch: 10},
+		},
+	})
+	require.NoError(t, err)
+
+	indices, err := st.SlashedValidatorIndices(10)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{0, 3}, indices)
+	indices, err = st.SlashedValidatorIndices(12)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(indices))
+}
+
+func TestBeaconState_EjectionCandidateIndices(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	ejectionBalance := params.BeaconConfig().EjectionBalance
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{EffectiveBalance: ejectionBalance, ActivationEpoch: 0, ExitEpoch: farFuture},
+			{EffectiveBalance: ejectionBalance + 1, ActivationEpoch: 0, ExitEpoch: farFuture},
+			{EffectiveBalance: ejectionBalance - 1, ActivationEpoch: 6, ExitEpoch: farFuture},
+			{EffectiveBalance: 0, ActivationEpoch: 0, ExitEpoch: 5},
+			{EffectiveBalance: 0, ActivationEpoch: 1, ExitEpoch: farFuture},
+		},
+	})
+	require.NoError(t, err)
+
+	indices, err := st.EjectionCandidateIndices(5)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{0, 4}, indices)
+	indices, err = st.EjectionCandidateIndices(6)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{0, 2, 4}, indices)
+}
+
+func TestBeaconState_PendingActivationIndices(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{ActivationEligibilityEpoch: 3, ActivationEpoch: farFuture},
+			{ActivationEligibilityEpoch: 1, ActivationEpoch: 5},
+			{ActivationEligibilityEpoch: 2, ActivationEpoch: farFuture},
+			{ActivationEligibilityEpoch: farFuture, ActivationEpoch: farFuture},
+			{ActivationEligibilityEpoch: 2, ActivationEpoch: farFuture},
+			{ActivationEligibilityEpoch: 4, ActivationEpoch: farFuture},
+		},
+	})
+	require.NoError(t, err)
+
+	indices, err := st.PendingActivationIndices(3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{2, 4, 0}, indices)
+	indices, err = st.PendingActivationIndices(1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(indices))
+}
+
+func TestBeaconState_EpochAttestationsLength(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		PreviousEpochAttestations: []*pb.PendingAttestation{{}, {}, {}},
+		CurrentEpochAttestations:  []*pb.PendingAttestation{{}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, st.PreviousEpochAttestationsLength())
+	assert.Equal(t, 1, st.CurrentEpochAttestationsLength())
+
+	require.NoError(t, st.AppendCurrentEpochAttestations(&pb.PendingAttestation{}))
+	assert.Equal(t, 2, st.CurrentEpochAttestationsLength())
+}
+
+func TestBeaconState_MatchJustifiedCheckpoints(t *testing.T) {
+	root1 := bytesutil.PadTo([]byte("root1"), 32)
+	root2 := bytesutil.PadTo([]byte("root2"), 32)
+	st, err := InitializeFromProto(&pb.BeaconState{
+		CurrentJustifiedCheckpoint:  &eth.Checkpoint{Epoch: 2, Root: root1},
+		PreviousJustifiedCheckpoint: &eth.Checkpoint{Epoch: 1, Root: root1},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, true, st.MatchCurrentJustifiedCheckpoint(&eth.Checkpoint{Epoch: 2, Root: root1}))
+	assert.Equal(t, false, st.MatchCurrentJustifiedCheckpoint(&eth.Checkpoint{Epoch: 2, Root: root2}))
+	assert.Equal(t, false, st.MatchCurrentJustifiedCheckpoint(&eth.Checkpoint{Epoch: 1, Root: root1}))
+	assert.Equal(t, false, st.MatchCurrentJustifiedCheckpoint(nil))
+
+	assert.Equal(t, true, st.MatchPreviousJustifiedCheckpoint(&eth.Checkpoint{Epoch: 1, Root: root1}))
+	assert.Equal(t, false, st.MatchPreviousJustifiedCheckpoint(&eth.Checkpoint{Epoch: 1, Root: root2}))
+}
+
+func TestBeaconState_ReadFromEveryAttestation(t *testing.T) {
+	prev := []*pb.PendingAttestation{{InclusionDelay: 1}, {InclusionDelay: 2}}
+	curr := []*pb.PendingAttestation{{InclusionDelay: 3}}
+	st, err := InitializeFromProto(&pb.BeaconState{
+		PreviousEpochAttestations: prev,
+		CurrentEpochAttestations:  curr,
+	})
+	require.NoError(t, err)
+
+	var delays []uint64
+	require.NoError(t, st.ReadFromEveryPreviousAttestation(func(idx int, att *pb.PendingAttestation) error {
+		assert.Equal(t, prev[idx], att, "Expected attestation to not be copied")
+		delays = append(delays, att.InclusionDelay)
+		return nil
+	}))
+	require.NoError(t, st.ReadFromEveryCurrentAttestation(func(idx int, att *pb.PendingAttestation) error {
+		assert.Equal(t, curr[idx], att, "Expected attestation to not be copied")
+		delays = append(delays, att.InclusionDelay)
+		return nil
+	}))
+	assert.DeepEqual(t, []uint64{1, 2, 3}, delays)
+
+	err = st.ReadFromEveryPreviousAttestation(func(idx int, att *pb.PendingAttestation) error {
+		return errors.New("stop")
+	})
+	assert.ErrorContains(t, "stop", err)
+}
+
+func TestBeaconState_EpochAttestationInclusion(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		PreviousEpochAttestations: []*pb.PendingAttestation{{InclusionDelay: 1, ProposerIndex: 10}, nil},
+		CurrentEpochAttestations:  []*pb.PendingAttestation{{InclusionDelay: 3, ProposerIndex: 30}},
+	})
+	require.NoError(t, err)
+
+	delay, proposer, err := st.PreviousEpochAttestationInclusion(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), delay)
+	assert.Equal(t, uint64(10), proposer)
+	delay, proposer, err = st.CurrentEpochAttestationInclusion(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), delay)
+	assert.Equal(t, uint64(30), proposer)
+
+	_, _, err = st.PreviousEpochAttestationInclusion(1)
+	assert.ErrorContains(t, "nil pending attestation at index 1", err)
+	_, _, err = st.PreviousEpochAttestationInclusion(2)
+	assert.ErrorContains(t, "index 2 out of range", err)
+	_, _, err = st.CurrentEpochAttestationInclusion(-1)
+	assert.ErrorContains(t, "index -1 out of range", err)
+}
+
+func TestBeaconState_Version(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	assert.Equal(t, Phase0, st.Version())
+	assert.Equal(t, Phase0, st.Copy().Version())
+
+	// Register a schema version which no longer defines the pending attestations.
+	laterVersion := Phase0 + 1
+	setUnsupportedFields(t, laterVersion, CurrentEpochAttestations)
+
+	require.NoError(t, st.ReadFromEveryCurrentAttestation(func(int, *pb.PendingAttestation) error { return nil }))
+	st.version = laterVersion
+	err = st.ReadFromEveryCurrentAttestation(func(int, *pb.PendingAttestation) error { return nil })
+	var notSupported *FieldNotSupportedError
+	require.Equal(t, true, errors.As(err, &notSupported))
+	assert.Equal(t, CurrentEpochAttestations, notSupported.Field)
+	assert.Equal(t, laterVersion, notSupported.Version)
+	assert.ErrorContains(t, "field currentEpochAttestations not supported at state version 1", err)
+	require.NoError(t, st.ReadFromEveryPreviousAttestation(func(int, *pb.PendingAttestation) error { return nil }))
+}
+
+func TestBeaconState_EpochAttestations_UnsupportedField(t *testing.T) {
+	atts := []*pb.PendingAttestation{{InclusionDelay: 1}, {InclusionDelay: 2}}
+	st, err := InitializeFromProto(&pb.BeaconState{
+		PreviousEpochAttestations: atts,
+		CurrentEpochAttestations:  atts,
+	})
+	require.NoError(t, err)
+	laterVersion := Phase0 + 1
+	setUnsupportedFields(t, laterVersion, PreviousEpochAttestations, CurrentEpochAttestations)
+
+	assert.Equal(t, 2, len(st.PreviousEpochAttestations()))
+	assert.Equal(t, 2, len(st.CurrentEpochAttestations()))
+	assert.Equal(t, 2, st.PreviousEpochAttestationsLength())
+	assert.Equal(t, 2, st.CurrentEpochAttestationsLength())
+
+	st.version = laterVersion
+	assert.Equal(t, 0, len(st.PreviousEpochAttestations()))
+	assert.Equal(t, 0, len(st.CurrentEpochAttestations()))
+	assert.Equal(t, 0, st.PreviousEpochAttestationsLength())
+	assert.Equal(t, 0, st.CurrentEpochAttestationsLength())
+	_, _, err = st.PreviousEpochAttestationInclusion(0)
+	var notSupported *FieldNotSupportedError
+	assert.Equal(t, true, errors.As(err, &notSupported))
+}
+
+// setUnsupportedFields marks the given fields as unsupported at the version for the
+// duration of the test. The version table is replaced by a copy rather than modified,
+// and restored once the test completes.
+func setUnsupportedFields(t *testing.T, version int, fields ...FieldIndex) {
+	original := unsupportedFields
+	table := make(map[int]map[FieldIndex]bool, len(original)+1)
+	for v, unsupported := range original {
+		table[v] = unsupported
+	}
+	table[version] = make(map[FieldIndex]bool, len(fields))
+	for _, field := range fields {
+		table[version][field] = true
+	}
+	unsupportedFields = table
+	t.Cleanup(func() {
+		unsupportedFields = original
+	})
+}
+
+func TestBeaconState_CloneValidators(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*eth.Validator{
+			{PublicKey: bytesutil.PadTo([]byte("a"), 48), EffectiveBalance: 1},
+			{PublicKey: bytesutil.PadTo([]byte("b"), 48), EffectiveBalance: 2},
+		},
+		Balances: []uint64{1, 2},
+	})
+	require.NoError(t, err)
+	vals := st.CloneValidators()
+	require.Equal(t, 2, len(vals))
+	assert.DeepEqual(t, st.Validators(), vals)
+
+	// Mutating the copy leaves the registry of the state untouched.
+	vals[0].EffectiveBalance = 100
+	vals[1].PublicKey[0] = 'c'
+	val, err := st.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), val.EffectiveBalance)
+	val, err = st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	assert.Equal(t, byte('b'), val.PublicKey[0])
+
+	empty, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(empty.CloneValidators()))
+}
+
+func TestBeaconState_ComputeFinalization(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	finalized := &eth.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("finalized"), 32)}
+	prevJustified := &eth.Checkpoint{Epoch: 2, Root: bytesutil.PadTo([]byte("previous"), 32)}
+	currJustified := &eth.Checkpoint{Epoch: 3, Root: bytesutil.PadTo([]byte("current"), 32)}
+	tests := []struct {
+		name  string
+		epoch uint64
+		bits  bitfield.Bitvector4
+		want  *eth.Checkpoint
+	}{
+		{name: "2nd/3rd/4th justified", epoch: 5, bits: bitfield.Bitvector4{0x0E}, want: prevJustified},
+		{name: "2nd/3rd justified", epoch: 4, bits: bitfield.Bitvector4{0x06}, want: prevJustified},
+		{name: "1st/2nd/3rd justified", epoch: 5, bits: bitfield.Bitvector4{0x07}, want: currJustified},
+		{name: "1st/2nd justified", epoch: 4, bits: bitfield.Bitvector4{0x03}, want: currJustified},
+		{name: "no rule applies", epoch: 6, bits: bitfield.Bitvector4{0x0F}, want: finalized},
+		{name: "missing justification", epoch: 4, bits: bitfield.Bitvector4{0x05}, want: finalized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := InitializeFromProto(&pb.BeaconState{
+				Slot:                        tt.epoch * slotsPerEpoch,
+				JustificationBits:           tt.bits,
+				PreviousJustifiedCheckpoint: prevJustified,
+				CurrentJustifiedCheckpoint:  currJustified,
+				FinalizedCheckpoint:         finalized,
+			})
+			require.NoError(t, err)
+			got, err := st.ComputeFinalization()
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.want, got)
+			// The state itself is left untouched.
+			assert.DeepEqual(t, finalized, st.FinalizedCheckpoint())
+		})
+	}
+}
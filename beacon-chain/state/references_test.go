@@ -18,22 +18,22 @@ func TestStateReferenceSharing_Finalizer(t *testing.T) {
 
 	a, err := InitializeFromProtoUnsafe(&p2ppb.BeaconState{RandaoMixes: [][]byte{[]byte("foo")}})
 	require.NoError(t, err)
-	assert.Equal(t, uint(1), a.sharedFieldReferences[randaoMixes].refs, "Expected a single reference for RANDAO mixes")
+	assert.Equal(t, uint(1), a.sharedFieldReferences[RandaoMixes].refs, "Expected a single reference for RANDAO mixes")
 
 	func() {
 		// Create object in a different scope for GC
 		b := a.Copy()
-		assert.Equal(t, uint(2), a.sharedFieldReferences[randaoMixes].refs, "Expected 2 references to RANDAO mixes")
+		assert.Equal(t, uint(2), a.sharedFieldReferences[RandaoMixes].refs, "Expected 2 references to RANDAO mixes")
 		_ = b
 	}()
 
 	runtime.GC() // Should run finalizer on object b
-	assert.Equal(t, uint(1), a.sharedFieldReferences[randaoMixes].refs, "Expected 1 shared reference to RANDAO mixes!")
+	assert.Equal(t, uint(1), a.sharedFieldReferences[RandaoMixes].refs, "Expected 1 shared reference to RANDAO mixes!")
 
 	b := a.Copy()
-	assert.Equal(t, uint(2), b.sharedFieldReferences[randaoMixes].refs, "Expected 2 shared references to RANDAO mixes")
-	require.NoError(t, b.UpdateRandaoMixesAtIndex(0, []byte("bar")))
-	if b.sharedFieldReferences[randaoMixes].refs != 1 || a.sharedFieldReferences[randaoMixes].refs != 1 {
+	assert.Equal(t, uint(2), b.sharedFieldReferences[RandaoMixes].refs, "Expected 2 shared references to RANDAO mixes")
+	require.NoError(t, b.UpdateRandaoMixesAtIndex(0, bytesutil.ToBytes32([]byte("bar"))))
+	if b.sharedFieldReferences[RandaoMixes].refs != 1 || a.sharedFieldReferences[RandaoMixes].refs != 1 {
 		t.Error("Expected 1 shared reference to RANDAO mix for both a and b")
 	}
 }
@@ -49,15 +49,15 @@ func TestStateReferenceCopy_NoUnexpectedRootsMutation(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	assertRefCount(t, a, blockRoots, 1)
-	assertRefCount(t, a, stateRoots, 1)
+	assertRefCount(t, a, BlockRoots, 1)
+	assertRefCount(t, a, StateRoots, 1)
 
 	// Copy, increases reference count.
 	b := a.Copy()
-	assertRefCount(t, a, blockRoots, 2)
-	assertRefCount(t, a, stateRoots, 2)
-	assertRefCount(t, b, blockRoots, 2)
-	assertRefCount(t, b, stateRoots, 2)
+	assertRefCount(t, a, BlockRoots, 2)
+	assertRefCount(t, a, StateRoots, 2)
+	assertRefCount(t, b, BlockRoots, 2)
+	assertRefCount(t, b, StateRoots, 2)
 	assert.Equal(t, 1, len(b.state.GetBlockRoots()), "No block roots found")
 	assert.Equal(t, 1, len(b.state.GetStateRoots()), "No state roots found")
 
@@ -100,27 +100,27 @@ func TestStateReferenceCopy_NoUnexpectedRootsMutation(t *testing.T) {
 	assert.DeepEqual(t, root1[:], stateRootsB[0], "Unexpected mutation found")
 
 	// Copy on write happened, reference counters are reset.
-	assertRefCount(t, a, blockRoots, 1)
-	assertRefCount(t, a, stateRoots, 1)
-	assertRefCount(t, b, blockRoots, 1)
-	assertRefCount(t, b, stateRoots, 1)
+	assertRefCount(t, a, BlockRoots, 1)
+	assertRefCount(t, a, StateRoots, 1)
+	assertRefCount(t, b, BlockRoots, 1)
+	assertRefCount(t, b, StateRoots, 1)
 }
 
 func TestStateReferenceCopy_NoUnexpectedRandaoMutation(t *testing.T) {
 
-	val1, val2 := []byte("foo"), []byte("bar")
+	val1, val2 := bytesutil.PadTo([]byte("foo"), 32), bytesutil.PadTo([]byte("bar"), 32)
 	a, err := InitializeFromProtoUnsafe(&p2ppb.BeaconState{
 		RandaoMixes: [][]byte{
 			val1,
 		},
 	})
 	require.NoError(t, err)
-	assertRefCount(t, a, randaoMixes, 1)
+	assertRefCount(t, a, RandaoMixes, 1)
 
 	// Copy, increases reference count.
 	b := a.Copy()
-	assertRefCount(t, a, randaoMixes, 2)
-	assertRefCount(t, b, randaoMixes, 2)
+	assertRefCount(t, a, RandaoMixes, 2)
+	assertRefCount(t, b, RandaoMixes, 2)
 	assert.Equal(t, 1, len(b.state.GetRandaoMixes()), "No randao mixes found")
 
 	// Assert shared state.
@@ -133,7 +133,7 @@ func TestStateReferenceCopy_NoUnexpectedRandaoMutation(t *testing.T) {
 	assertValFound(t, mixesB, val1)
 
 	// Mutator should only affect calling state: a.
-	require.NoError(t, a.UpdateRandaoMixesAtIndex(0, val2))
+	require.NoError(t, a.UpdateRandaoMixesAtIndex(0, bytesutil.ToBytes32(val2)))
 
 	// Assert no shared state mutation occurred only on state a (copy on write).
 	if len(mixesA) != len(mixesB) || len(mixesA) < 1 {
@@ -149,8 +149,8 @@ func TestStateReferenceCopy_NoUnexpectedRandaoMutation(t *testing.T) {
 	assert.DeepEqual(t, val1, mixesB[0], "Unexpected mutation found")
 
 	// Copy on write happened, reference counters are reset.
-	assertRefCount(t, a, randaoMixes, 1)
-	assertRefCount(t, b, randaoMixes, 1)
+	assertRefCount(t, a, RandaoMixes, 1)
+	assertRefCount(t, b, RandaoMixes, 1)
 }
 
 func TestStateReferenceCopy_NoUnexpectedAttestationsMutation(t *testing.T) {
@@ -175,8 +175,8 @@ func TestStateReferenceCopy_NoUnexpectedAttestationsMutation(t *testing.T) {
 
 	a, err := InitializeFromProtoUnsafe(&p2ppb.BeaconState{})
 	require.NoError(t, err)
-	assertRefCount(t, a, previousEpochAttestations, 1)
-	assertRefCount(t, a, currentEpochAttestations, 1)
+	assertRefCount(t, a, PreviousEpochAttestations, 1)
+	assertRefCount(t, a, CurrentEpochAttestations, 1)
 
 	// Update initial state.
 	atts := []*p2ppb.PendingAttestation{
@@ -190,10 +190,10 @@ func TestStateReferenceCopy_NoUnexpectedAttestationsMutation(t *testing.T) {
 
 	// Copy, increases reference count.
 	b := a.Copy()
-	assertRefCount(t, a, previousEpochAttestations, 2)
-	assertRefCount(t, a, currentEpochAttestations, 2)
-	assertRefCount(t, b, previousEpochAttestations, 2)
-	assertRefCount(t, b, currentEpochAttestations, 2)
+	assertRefCount(t, a, PreviousEpochAttestations, 2)
+	assertRefCount(t, a, CurrentEpochAttestations, 2)
+	assertRefCount(t, b, PreviousEpochAttestations, 2)
+	assertRefCount(t, b, CurrentEpochAttestations, 2)
 	assert.Equal(t, 1, len(b.state.GetPreviousEpochAttestations()), "Unexpected number of attestations")
 	assert.Equal(t, 1, len(b.state.GetCurrentEpochAttestations()), "Unexpected number of attestations")
 
@@ -266,15 +266,15 @@ func TestStateReferenceCopy_NoUnexpectedAttestationsMutation(t *testing.T) {
 	assertAttNotFound(b.state.GetPreviousEpochAttestations(), 2)
 
 	// Copy on write happened, reference counters are reset.
-	assertRefCount(t, a, currentEpochAttestations, 1)
-	assertRefCount(t, b, currentEpochAttestations, 1)
-	assertRefCount(t, a, previousEpochAttestations, 1)
-	assertRefCount(t, b, previousEpochAttestations, 1)
+	assertRefCount(t, a, CurrentEpochAttestations, 1)
+	assertRefCount(t, b, CurrentEpochAttestations, 1)
+	assertRefCount(t, a, PreviousEpochAttestations, 1)
+	assertRefCount(t, b, PreviousEpochAttestations, 1)
 }
 
 // assertRefCount checks whether reference count for a given state
 // at a given index is equal to expected amount.
-func assertRefCount(t *testing.T, b *BeaconState, idx fieldIndex, want uint) {
+func assertRefCount(t *testing.T, b *BeaconState, idx FieldIndex, want uint) {
 	if cnt := b.sharedFieldReferences[idx].refs; cnt != want {
 		t.Errorf("Unexpected count of references for index %d, want: %v, got: %v", idx, want, cnt)
 	}
@@ -2,15 +2,16 @@ package state
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
-	coreutils "github.com/prysmaticlabs/prysm/beacon-chain/core/state/stateutils"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 // For our setters, we have a field reference counter through
@@ -39,7 +40,7 @@ func (b *BeaconState) SetGenesisTime(val uint64) error {
 	defer b.lock.Unlock()
 
 	b.state.GenesisTime = val
-	b.markFieldAsDirty(genesisTime)
+	b.markFieldAsDirty(GenesisTime)
 	return nil
 }
 
@@ -49,7 +50,7 @@ func (b *BeaconState) SetGenesisValidatorRoot(val []byte) error {
 	defer b.lock.Unlock()
 
 	b.state.GenesisValidatorsRoot = val
-	b.markFieldAsDirty(genesisValidatorRoot)
+	b.markFieldAsDirty(GenesisValidatorRoot)
 	return nil
 }
 
@@ -62,7 +63,24 @@ func (b *BeaconState) SetSlot(val uint64) error {
 	defer b.lock.Unlock()
 
 	b.state.Slot = val
-	b.markFieldAsDirty(slot)
+	b.markFieldAsDirty(Slot)
+	return nil
+}
+
+// AdvanceSlot increments the slot of the beacon state by one, returning
+// an error if doing so would overflow. Use SetSlot for arbitrary jumps.
+func (b *BeaconState) AdvanceSlot() error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state.Slot == math.MaxUint64 {
+		return errors.New("slot overflow")
+	}
+	b.state.Slot++
+	b.markFieldAsDirty(Slot)
 	return nil
 }
 
@@ -79,20 +97,33 @@ func (b *BeaconState) SetFork(val *pbp2p.Fork) error {
 		return errors.New("proto.Clone did not return a fork proto")
 	}
 	b.state.Fork = fk
-	b.markFieldAsDirty(fork)
+	b.markFieldAsDirty(Fork)
 	return nil
 }
 
-// SetLatestBlockHeader in the beacon state.
+// SetLatestBlockHeader in the beacon state. The parent, state and body
+// roots must be 32 bytes long when set, and the header is deep copied.
 func (b *BeaconState) SetLatestBlockHeader(val *ethpb.BeaconBlockHeader) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
 	}
+	if val == nil {
+		return errors.New("nil block header")
+	}
+	if len(val.ParentRoot) != 0 && len(val.ParentRoot) != 32 {
+		return errors.Errorf("block header parent root must be 32 bytes, received %d", len(val.ParentRoot))
+	}
+	if len(val.StateRoot) != 0 && len(val.StateRoot) != 32 {
+		return errors.Errorf("block header state root must be 32 bytes, received %d", len(val.StateRoot))
+	}
+	if len(val.BodyRoot) != 0 && len(val.BodyRoot) != 32 {
+		return errors.Errorf("block header body root must be 32 bytes, received %d", len(val.BodyRoot))
+	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
 	b.state.LatestBlockHeader = CopyBeaconBlockHeader(val)
-	b.markFieldAsDirty(latestBlockHeader)
+	b.markFieldAsDirty(LatestBlockHeader)
 	return nil
 }
 
@@ -105,12 +136,12 @@ func (b *BeaconState) SetBlockRoots(val [][]byte) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[blockRoots].MinusRef()
-	b.sharedFieldReferences[blockRoots] = &reference{refs: 1}
+	b.sharedFieldReferences[BlockRoots].MinusRef()
+	b.sharedFieldReferences[BlockRoots] = &reference{refs: 1}
 
 	b.state.BlockRoots = val
-	b.markFieldAsDirty(blockRoots)
-	b.rebuildTrie[blockRoots] = true
+	b.markFieldAsDirty(BlockRoots)
+	b.rebuildTrie[BlockRoots] = true
 	return nil
 }
 
@@ -127,19 +158,19 @@ func (b *BeaconState) UpdateBlockRootAtIndex(idx uint64, blockRoot [32]byte) err
 	defer b.lock.Unlock()
 
 	r := b.state.BlockRoots
-	if ref := b.sharedFieldReferences[blockRoots]; ref.Refs() > 1 {
+	if ref := b.sharedFieldReferences[BlockRoots]; ref.Refs() > 1 {
 		// Copy elements in underlying array by reference.
 		r = make([][]byte, len(b.state.BlockRoots))
 		copy(r, b.state.BlockRoots)
 		ref.MinusRef()
-		b.sharedFieldReferences[blockRoots] = &reference{refs: 1}
+		b.sharedFieldReferences[BlockRoots] = &reference{refs: 1}
 	}
 
 	r[idx] = blockRoot[:]
 	b.state.BlockRoots = r
 
-	b.markFieldAsDirty(blockRoots)
-	b.addDirtyIndices(blockRoots, []uint64{idx})
+	b.markFieldAsDirty(BlockRoots)
+	b.addDirtyIndices(BlockRoots, []uint64{idx})
 	return nil
 }
 
@@ -152,12 +183,12 @@ func (b *BeaconState) SetStateRoots(val [][]byte) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[stateRoots].MinusRef()
-	b.sharedFieldReferences[stateRoots] = &reference{refs: 1}
+	b.sharedFieldReferences[StateRoots].MinusRef()
+	b.sharedFieldReferences[StateRoots] = &reference{refs: 1}
 
 	b.state.StateRoots = val
-	b.markFieldAsDirty(stateRoots)
-	b.rebuildTrie[stateRoots] = true
+	b.markFieldAsDirty(StateRoots)
+	b.rebuildTrie[StateRoots] = true
 	return nil
 }
 
@@ -180,54 +211,74 @@ func (b *BeaconState) UpdateStateRootAtIndex(idx uint64, stateRoot [32]byte) err
 
 	// Check if we hold the only reference to the shared state roots slice.
 	r := b.state.StateRoots
-	if ref := b.sharedFieldReferences[stateRoots]; ref.Refs() > 1 {
+	if ref := b.sharedFieldReferences[StateRoots]; ref.Refs() > 1 {
 		// Copy elements in underlying array by reference.
 		r = make([][]byte, len(b.state.StateRoots))
 		copy(r, b.state.StateRoots)
 		ref.MinusRef()
-		b.sharedFieldReferences[stateRoots] = &reference{refs: 1}
+		b.sharedFieldReferences[StateRoots] = &reference{refs: 1}
 	}
 
 	r[idx] = stateRoot[:]
 	b.state.StateRoots = r
 
-	b.markFieldAsDirty(stateRoots)
-	b.addDirtyIndices(stateRoots, []uint64{idx})
+	b.markFieldAsDirty(StateRoots)
+	b.addDirtyIndices(StateRoots, []uint64{idx})
 	return nil
 }
 
 // SetHistoricalRoots for the beacon state. Updates the entire
-// list to a new value by overwriting the previous one.
+// list to a deep copy of the provided roots, overwriting the
+// previous one. Every root must be 32 bytes long.
 func (b *BeaconState) SetHistoricalRoots(val [][]byte) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
 	}
+	roots := make([][]byte, len(val))
+	for i, root := range val {
+		if len(root) != 32 {
+			return errors.Errorf("historical root at index %d must be 32 bytes, received %d", i, len(root))
+		}
+		roots[i] = bytesutil.SafeCopyBytes(root)
+	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[historicalRoots].MinusRef()
-	b.sharedFieldReferences[historicalRoots] = &reference{refs: 1}
+	b.sharedFieldReferences[HistoricalRoots].MinusRef()
+	b.sharedFieldReferences[HistoricalRoots] = &reference{refs: 1}
 
-	b.state.HistoricalRoots = val
-	b.markFieldAsDirty(historicalRoots)
+	b.state.HistoricalRoots = roots
+	b.markFieldAsDirty(HistoricalRoots)
 	return nil
 }
 
-// SetEth1Data for the beacon state.
+// SetEth1Data for the beacon state. The deposit root and block hash
+// must be 32 bytes long when set, and the eth1 data is deep copied.
 func (b *BeaconState) SetEth1Data(val *ethpb.Eth1Data) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
 	}
+	if val == nil {
+		return errors.New("nil eth1 data")
+	}
+	if len(val.DepositRoot) != 0 && len(val.DepositRoot) != 32 {
+		return errors.Errorf("eth1 data deposit root must be 32 bytes, received %d", len(val.DepositRoot))
+	}
+	if len(val.BlockHash) != 0 && len(val.BlockHash) != 32 {
+		return errors.Errorf("eth1 data block hash must be 32 bytes, received %d", len(val.BlockHash))
+	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.state.Eth1Data = val
-	b.markFieldAsDirty(eth1Data)
+	b.state.Eth1Data = CopyETH1Data(val)
+	b.markFieldAsDirty(Eth1Data)
 	return nil
 }
 
 // SetEth1DataVotes for the beacon state. Updates the entire
-// list to a new value by overwriting the previous one.
+// list to a deep copy of the provided votes, overwriting the
+// previous one. An empty list clears the votes, as is done at
+// the end of each eth1 voting period.
 func (b *BeaconState) SetEth1DataVotes(val []*ethpb.Eth1Data) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -235,17 +286,22 @@ func (b *BeaconState) SetEth1DataVotes(val []*ethpb.Eth1Data) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[eth1DataVotes].MinusRef()
-	b.sharedFieldReferences[eth1DataVotes] = &reference{refs: 1}
+	b.sharedFieldReferences[Eth1DataVotes].MinusRef()
+	b.sharedFieldReferences[Eth1DataVotes] = &reference{refs: 1}
 
-	b.state.Eth1DataVotes = val
-	b.markFieldAsDirty(eth1DataVotes)
-	b.rebuildTrie[eth1DataVotes] = true
+	votes := make([]*ethpb.Eth1Data, len(val))
+	for i, v := range val {
+		votes[i] = CopyETH1Data(v)
+	}
+	b.state.Eth1DataVotes = votes
+	b.markFieldAsDirty(Eth1DataVotes)
+	b.rebuildTrie[Eth1DataVotes] = true
 	return nil
 }
 
-// AppendEth1DataVotes for the beacon state. Appends the new value
-// to the the end of list.
+// AppendEth1DataVotes for the beacon state. Appends a deep copy of
+// the new value to the end of list, so that later mutations by the
+// caller cannot leak into the state.
 func (b *BeaconState) AppendEth1DataVotes(val *ethpb.Eth1Data) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -254,17 +310,49 @@ func (b *BeaconState) AppendEth1DataVotes(val *ethpb.Eth1Data) error {
 	defer b.lock.Unlock()
 
 	votes := b.state.Eth1DataVotes
-	if b.sharedFieldReferences[eth1DataVotes].Refs() > 1 {
+	if b.sharedFieldReferences[Eth1DataVotes].Refs() > 1 {
+		// Copy elements in underlying array by reference.
+		votes = make([]*ethpb.Eth1Data, len(b.state.Eth1DataVotes))
+		copy(votes, b.state.Eth1DataVotes)
+		b.sharedFieldReferences[Eth1DataVotes].MinusRef()
+		b.sharedFieldReferences[Eth1DataVotes] = &reference{refs: 1}
+	}
+
+	b.state.Eth1DataVotes = append(votes, CopyETH1Data(val))
+	b.markFieldAsDirty(Eth1DataVotes)
+	b.addDirtyIndices(Eth1DataVotes, []uint64{uint64(len(b.state.Eth1DataVotes) - 1)})
+	return nil
+}
+
+// UpdateEth1DataVotesAtIndex for the beacon state. Replaces the vote at
+// the provided index with a deep copy of the new value.
+func (b *BeaconState) UpdateEth1DataVotesAtIndex(idx uint64, val *ethpb.Eth1Data) error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	if val == nil {
+		return errors.New("nil eth1 data")
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if uint64(len(b.state.Eth1DataVotes)) <= idx {
+		return errors.Errorf("invalid index provided %d", idx)
+	}
+
+	votes := b.state.Eth1DataVotes
+	if b.sharedFieldReferences[Eth1DataVotes].Refs() > 1 {
 		// Copy elements in underlying array by reference.
 		votes = make([]*ethpb.Eth1Data, len(b.state.Eth1DataVotes))
 		copy(votes, b.state.Eth1DataVotes)
-		b.sharedFieldReferences[eth1DataVotes].MinusRef()
-		b.sharedFieldReferences[eth1DataVotes] = &reference{refs: 1}
+		b.sharedFieldReferences[Eth1DataVotes].MinusRef()
+		b.sharedFieldReferences[Eth1DataVotes] = &reference{refs: 1}
 	}
 
-	b.state.Eth1DataVotes = append(votes, val)
-	b.markFieldAsDirty(eth1DataVotes)
-	b.addDirtyIndices(eth1DataVotes, []uint64{uint64(len(b.state.Eth1DataVotes) - 1)})
+	votes[idx] = CopyETH1Data(val)
+	b.state.Eth1DataVotes = votes
+	b.markFieldAsDirty(Eth1DataVotes)
+	b.addDirtyIndices(Eth1DataVotes, []uint64{idx})
 	return nil
 }
 
@@ -277,12 +365,14 @@ func (b *BeaconState) SetEth1DepositIndex(val uint64) error {
 	defer b.lock.Unlock()
 
 	b.state.Eth1DepositIndex = val
-	b.markFieldAsDirty(eth1DepositIndex)
+	b.markFieldAsDirty(Eth1DepositIndex)
 	return nil
 }
 
 // SetValidators for the beacon state. Updates the entire
-// to a new value by overwriting the previous one.
+// registry to a deep copy of the provided validators, overwriting
+// the previous one, and rebuilds the public key index map in the
+// same pass.
 func (b *BeaconState) SetValidators(val []*ethpb.Validator) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -290,13 +380,25 @@ func (b *BeaconState) SetValidators(val []*ethpb.Validator) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.state.Validators = val
-	b.sharedFieldReferences[validators].MinusRef()
-	b.sharedFieldReferences[validators] = &reference{refs: 1}
-	b.markFieldAsDirty(validators)
-	b.rebuildTrie[validators] = true
+	vals := make([]*ethpb.Validator, len(val))
+	idxMap := make(map[[48]byte]uint64, len(val))
+	for i, v := range val {
+		if v == nil {
+			continue
+		}
+		vals[i] = CopyValidator(v)
+		idxMap[bytesutil.ToBytes48(v.PublicKey)] = uint64(i)
+	}
+	b.state.Validators = vals
+	b.sharedFieldReferences[Validators].MinusRef()
+	b.sharedFieldReferences[Validators] = &reference{refs: 1}
+	b.markFieldAsDirty(Validators)
+	b.rebuildTrie[Validators] = true
+	for i := range vals {
+		b.dirtyValidators[uint64(i)] = true
+	}
 	b.valMapHandler = &validatorMapHandler{
-		valIdxMap: coreutils.ValidatorIndexMap(b.state.Validators),
+		valIdxMap: idxMap,
 		mapRef:    &reference{refs: 1},
 	}
 	return nil
@@ -310,12 +412,12 @@ func (b *BeaconState) ApplyToEveryValidator(f func(idx int, val *ethpb.Validator
 	}
 	b.lock.Lock()
 	v := b.state.Validators
-	if ref := b.sharedFieldReferences[validators]; ref.Refs() > 1 {
+	if ref := b.sharedFieldReferences[Validators]; ref.Refs() > 1 {
 		// Perform a copy since this is a shared reference and we don't want to mutate others.
 		v = b.validators()
 
 		ref.MinusRef()
-		b.sharedFieldReferences[validators] = &reference{refs: 1}
+		b.sharedFieldReferences[Validators] = &reference{refs: 1}
 	}
 	b.lock.Unlock()
 	var changedVals []uint64
@@ -333,9 +435,64 @@ func (b *BeaconState) ApplyToEveryValidator(f func(idx int, val *ethpb.Validator
 	defer b.lock.Unlock()
 
 	b.state.Validators = v
-	b.markFieldAsDirty(validators)
-	b.addDirtyIndices(validators, changedVals)
+	b.markFieldAsDirty(Validators)
+	b.addDirtyIndices(Validators, changedVals)
+
+	return nil
+}
+
+// RecomputeEffectiveBalances updates the effective balance of every validator from its
+// balance, applying the hysteresis of the spec. The registry is iterated in place and
+// only the validators whose effective balance changes are written back and marked as
+// dirty, so nothing is copied when no effective balance changes.
+func (b *BeaconState) RecomputeEffectiveBalances() error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	effBalanceInc := params.BeaconConfig().EffectiveBalanceIncrement
+	maxEffBalance := params.BeaconConfig().MaxEffectiveBalance
+	hysteresisInc := effBalanceInc / params.BeaconConfig().HysteresisQuotient
+	downwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisDownwardMultiplier
+	upwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisUpwardMultiplier
 
+	var changedVals, effBalances []uint64
+	for i, val := range b.state.Validators {
+		if val == nil {
+			return fmt.Errorf("validator %d is nil in state", i)
+		}
+		if i >= len(b.state.Balances) {
+			return fmt.Errorf("validator index exceeds validator length in state %d >= %d", i, len(b.state.Balances))
+		}
+		balance := b.state.Balances[i]
+		if balance+downwardThreshold < val.EffectiveBalance || val.EffectiveBalance+upwardThreshold < balance {
+			effBalance := maxEffBalance
+			if effBalance > balance-balance%effBalanceInc {
+				effBalance = balance - balance%effBalanceInc
+			}
+			if effBalance != val.EffectiveBalance {
+				changedVals = append(changedVals, uint64(i))
+				effBalances = append(effBalances, effBalance)
+			}
+		}
+	}
+	if len(changedVals) == 0 {
+		return nil
+	}
+
+	if ref := b.sharedFieldReferences[Validators]; ref.Refs() > 1 {
+		// Perform a copy since this is a shared reference and we don't want to mutate others.
+		b.state.Validators = b.validators()
+		ref.MinusRef()
+		b.sharedFieldReferences[Validators] = &reference{refs: 1}
+	}
+	for i, idx := range changedVals {
+		b.state.Validators[idx].EffectiveBalance = effBalances[i]
+	}
+	b.markFieldAsDirty(Validators)
+	b.addDirtyIndices(Validators, changedVals)
 	return nil
 }
 
@@ -352,22 +509,90 @@ func (b *BeaconState) UpdateValidatorAtIndex(idx uint64, val *ethpb.Validator) e
 	defer b.lock.Unlock()
 
 	v := b.state.Validators
-	if ref := b.sharedFieldReferences[validators]; ref.Refs() > 1 {
+	if ref := b.sharedFieldReferences[Validators]; ref.Refs() > 1 {
 		// Perform a copy since this is a shared reference and we don't want to mutate others.
 		v = b.validators()
 
 		ref.MinusRef()
-		b.sharedFieldReferences[validators] = &reference{refs: 1}
+		b.sharedFieldReferences[Validators] = &reference{refs: 1}
 	}
 
 	v[idx] = val
 	b.state.Validators = v
-	b.markFieldAsDirty(validators)
-	b.addDirtyIndices(validators, []uint64{idx})
+	b.markFieldAsDirty(Validators)
+	b.addDirtyIndices(Validators, []uint64{idx})
 
 	return nil
 }
 
+// SetValidatorActivationEligibility sets the activation eligibility epoch of the
+// validator at the given index, mutating only that field of the validator.
+func (b *BeaconState) SetValidatorActivationEligibility(idx, epoch uint64) error {
+	return b.updateValidatorInPlace(idx, func(val *ethpb.Validator) {
+		val.ActivationEligibilityEpoch = epoch
+	})
+}
+
+// SetValidatorActivationEpoch sets the activation epoch of the validator at the
+// given index, mutating only that field of the validator.
+func (b *BeaconState) SetValidatorActivationEpoch(idx, epoch uint64) error {
+	return b.updateValidatorInPlace(idx, func(val *ethpb.Validator) {
+		val.ActivationEpoch = epoch
+	})
+}
+
+// SetValidatorSlashed marks the validator at the given index as slashed,
+// mutating only that field of the validator.
+func (b *BeaconState) SetValidatorSlashed(idx uint64) error {
+	return b.updateValidatorInPlace(idx, func(val *ethpb.Validator) {
+		val.Slashed = true
+	})
+}
+
+// SetValidatorExitEpoch sets the exit epoch of the validator at the given
+// index, mutating only that field of the validator.
+func (b *BeaconState) SetValidatorExitEpoch(idx, epoch uint64) error {
+	return b.updateValidatorInPlace(idx, func(val *ethpb.Validator) {
+		val.ExitEpoch = epoch
+	})
+}
+
+// SetValidatorWithdrawableEpoch sets the withdrawable epoch of the validator at
+// the given index, mutating only that field of the validator.
+func (b *BeaconState) SetValidatorWithdrawableEpoch(idx, epoch uint64) error {
+	return b.updateValidatorInPlace(idx, func(val *ethpb.Validator) {
+		val.WithdrawableEpoch = epoch
+	})
+}
+
+// updateValidatorInPlace applies the given mutation to the validator at the given
+// index, copying the registry first if it is shared with other states, and marks
+// only that validator as dirty.
+func (b *BeaconState) updateValidatorInPlace(idx uint64, mutate func(val *ethpb.Validator)) error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if uint64(len(b.state.Validators)) <= idx {
+		return errors.Errorf("invalid index provided %d", idx)
+	}
+	if b.state.Validators[idx] == nil {
+		return errors.Errorf("nil validator at index %d", idx)
+	}
+	if ref := b.sharedFieldReferences[Validators]; ref.Refs() > 1 {
+		// Perform a copy since this is a shared reference and we don't want to mutate others.
+		b.state.Validators = b.validators()
+		ref.MinusRef()
+		b.sharedFieldReferences[Validators] = &reference{refs: 1}
+	}
+	mutate(b.state.Validators[idx])
+	b.markFieldAsDirty(Validators)
+	b.addDirtyIndices(Validators, []uint64{idx})
+	return nil
+}
+
 // SetValidatorIndexByPubkey updates the validator index mapping maintained internally to
 // a given input 48-byte, public key.
 func (b *BeaconState) SetValidatorIndexByPubkey(pubKey [48]byte, validatorIdx uint64) {
@@ -383,7 +608,9 @@ func (b *BeaconState) SetValidatorIndexByPubkey(pubKey [48]byte, validatorIdx ui
 }
 
 // SetBalances for the beacon state. Updates the entire
-// list to a new value by overwriting the previous one.
+// list to a new value by overwriting the previous one. The
+// input is copied in one go, so the caller may reuse it, and
+// the balances trie is rebuilt on the next hash.
 func (b *BeaconState) SetBalances(val []uint64) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -391,11 +618,14 @@ func (b *BeaconState) SetBalances(val []uint64) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[balances].MinusRef()
-	b.sharedFieldReferences[balances] = &reference{refs: 1}
+	b.sharedFieldReferences[Balances].MinusRef()
+	b.sharedFieldReferences[Balances] = &reference{refs: 1}
 
-	b.state.Balances = val
-	b.markFieldAsDirty(balances)
+	bals := make([]uint64, len(val))
+	copy(bals, val)
+	b.state.Balances = bals
+	b.markFieldAsDirty(Balances)
+	b.rebuildTrie[Balances] = true
 	return nil
 }
 
@@ -412,15 +642,106 @@ func (b *BeaconState) UpdateBalancesAtIndex(idx, val uint64) error {
 	defer b.lock.Unlock()
 
 	bals := b.state.Balances
-	if b.sharedFieldReferences[balances].Refs() > 1 {
+	if b.sharedFieldReferences[Balances].Refs() > 1 {
 		bals = b.balances()
-		b.sharedFieldReferences[balances].MinusRef()
-		b.sharedFieldReferences[balances] = &reference{refs: 1}
+		b.sharedFieldReferences[Balances].MinusRef()
+		b.sharedFieldReferences[Balances] = &reference{refs: 1}
 	}
 
 	bals[idx] = val
 	b.state.Balances = bals
-	b.markFieldAsDirty(balances)
+	b.markFieldAsDirty(Balances)
+	b.addDirtyIndices(Balances, []uint64{idx})
+	return nil
+}
+
+// IncreaseBalance increases the balance of the validator at the provided
+// index by delta in Gwei.
+func (b *BeaconState) IncreaseBalance(idx, delta uint64) error {
+	return b.applyToBalanceAtIndex(idx, func(bal uint64) uint64 {
+		return bal + delta
+	})
+}
+
+// DecreaseBalance decreases the balance of the validator at the provided
+// index by delta in Gwei, with underflow protection.
+func (b *BeaconState) DecreaseBalance(idx, delta uint64) error {
+	return b.applyToBalanceAtIndex(idx, func(bal uint64) uint64 {
+		if delta > bal {
+			return 0
+		}
+		return bal - delta
+	})
+}
+
+// ApplyBalanceDeltas adds the signed delta in Gwei at each index to the balance of
+// the validator at that index, with underflow protection, saturating at math.MaxUint64
+// on overflow. All the balances are updated in a single pass and the balances are
+// only marked as dirty once, which is much cheaper than calling IncreaseBalance or DecreaseBalance for every index.
+func (b *BeaconState) ApplyBalanceDeltas(deltas []int64) error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(deltas) != len(b.state.Balances) {
+		return errors.Errorf("deltas length %d does not match balances length %d", len(deltas), len(b.state.Balances))
+	}
+
+	bals := b.state.Balances
+	if b.sharedFieldReferences[Balances].Refs() > 1 {
+		bals = b.balances()
+		b.sharedFieldReferences[Balances].MinusRef()
+		b.sharedFieldReferences[Balances] = &reference{refs: 1}
+	}
+
+	for i, delta := range deltas {
+		if delta >= 0 {
+			if increase := uint64(delta); increase > math.MaxUint64-bals[i] {
+				bals[i] = math.MaxUint64
+			} else {
+				bals[i] += increase
+			}
+			continue
+		}
+		decrease := uint64(-delta)
+		if decrease > bals[i] {
+			bals[i] = 0
+		} else {
+			bals[i] -= decrease
+		}
+	}
+	b.state.Balances = bals
+	b.markFieldAsDirty(Balances)
+	b.rebuildTrie[Balances] = true
+	return nil
+}
+
+// applyToBalanceAtIndex replaces the balance at the provided index with the
+// result of the provided function, performing the read and write under a single lock.
+func (b *BeaconState) applyToBalanceAtIndex(idx uint64, f func(bal uint64) uint64) error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if uint64(len(b.state.Balances)) <= idx {
+		return errors.Errorf("invalid index provided %d", idx)
+	}
+
+	bals := b.state.Balances
+	if b.sharedFieldReferences[Balances].Refs() > 1 {
+		bals = b.balances()
+		b.sharedFieldReferences[Balances].MinusRef()
+		b.sharedFieldReferences[Balances] = &reference{refs: 1}
+	}
+
+	bals[idx] = f(bals[idx])
+	b.state.Balances = bals
+	b.markFieldAsDirty(Balances)
+	b.addDirtyIndices(Balances, []uint64{idx})
 	return nil
 }
 
@@ -433,18 +754,18 @@ func (b *BeaconState) SetRandaoMixes(val [][]byte) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[randaoMixes].MinusRef()
-	b.sharedFieldReferences[randaoMixes] = &reference{refs: 1}
+	b.sharedFieldReferences[RandaoMixes].MinusRef()
+	b.sharedFieldReferences[RandaoMixes] = &reference{refs: 1}
 
 	b.state.RandaoMixes = val
-	b.markFieldAsDirty(randaoMixes)
-	b.rebuildTrie[randaoMixes] = true
+	b.markFieldAsDirty(RandaoMixes)
+	b.rebuildTrie[RandaoMixes] = true
 	return nil
 }
 
 // UpdateRandaoMixesAtIndex for the beacon state. Updates the randao mixes
 // at a specific index to a new value.
-func (b *BeaconState) UpdateRandaoMixesAtIndex(idx uint64, val []byte) error {
+func (b *BeaconState) UpdateRandaoMixesAtIndex(idx uint64, val [32]byte) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
 	}
@@ -455,24 +776,24 @@ func (b *BeaconState) UpdateRandaoMixesAtIndex(idx uint64, val []byte) error {
 	defer b.lock.Unlock()
 
 	mixes := b.state.RandaoMixes
-	if refs := b.sharedFieldReferences[randaoMixes].Refs(); refs > 1 {
+	if refs := b.sharedFieldReferences[RandaoMixes].Refs(); refs > 1 {
 		// Copy elements in underlying array by reference.
 		mixes = make([][]byte, len(b.state.RandaoMixes))
 		copy(mixes, b.state.RandaoMixes)
-		b.sharedFieldReferences[randaoMixes].MinusRef()
-		b.sharedFieldReferences[randaoMixes] = &reference{refs: 1}
+		b.sharedFieldReferences[RandaoMixes].MinusRef()
+		b.sharedFieldReferences[RandaoMixes] = &reference{refs: 1}
 	}
 
-	mixes[idx] = val
+	mixes[idx] = val[:]
 	b.state.RandaoMixes = mixes
-	b.markFieldAsDirty(randaoMixes)
-	b.addDirtyIndices(randaoMixes, []uint64{idx})
+	b.markFieldAsDirty(RandaoMixes)
+	b.addDirtyIndices(RandaoMixes, []uint64{idx})
 
 	return nil
 }
 
 // SetSlashings for the beacon state. Updates the entire
-// list to a new value by overwriting the previous one.
+// list to a copy of the provided value by overwriting the previous one.
 func (b *BeaconState) SetSlashings(val []uint64) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -480,38 +801,42 @@ func (b *BeaconState) SetSlashings(val []uint64) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[slashings].MinusRef()
-	b.sharedFieldReferences[slashings] = &reference{refs: 1}
+	b.sharedFieldReferences[Slashings].MinusRef()
+	b.sharedFieldReferences[Slashings] = &reference{refs: 1}
 
-	b.state.Slashings = val
-	b.markFieldAsDirty(slashings)
+	slashings := make([]uint64, len(val))
+	copy(slashings, val)
+	b.state.Slashings = slashings
+	b.markFieldAsDirty(Slashings)
 	return nil
 }
 
 // UpdateSlashingsAtIndex for the beacon state. Updates the slashings
-// at a specific index to a new value.
+// at a specific index to a new value, such as resetting the slot of
+// the next epoch at each epoch transition.
 func (b *BeaconState) UpdateSlashingsAtIndex(idx, val uint64) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
 	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
 	if uint64(len(b.state.Slashings)) <= idx {
 		return errors.Errorf("invalid index provided %d", idx)
 	}
-	b.lock.Lock()
-	defer b.lock.Unlock()
 
 	s := b.state.Slashings
-	if b.sharedFieldReferences[slashings].Refs() > 1 {
+	if b.sharedFieldReferences[Slashings].Refs() > 1 {
 		s = b.slashings()
-		b.sharedFieldReferences[slashings].MinusRef()
-		b.sharedFieldReferences[slashings] = &reference{refs: 1}
+		b.sharedFieldReferences[Slashings].MinusRef()
+		b.sharedFieldReferences[Slashings] = &reference{refs: 1}
 	}
 
 	s[idx] = val
 
 	b.state.Slashings = s
 
-	b.markFieldAsDirty(slashings)
+	b.markFieldAsDirty(Slashings)
 	return nil
 }
 
@@ -524,12 +849,12 @@ func (b *BeaconState) SetPreviousEpochAttestations(val []*pbp2p.PendingAttestati
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[previousEpochAttestations].MinusRef()
-	b.sharedFieldReferences[previousEpochAttestations] = &reference{refs: 1}
+	b.sharedFieldReferences[PreviousEpochAttestations].MinusRef()
+	b.sharedFieldReferences[PreviousEpochAttestations] = &reference{refs: 1}
 
 	b.state.PreviousEpochAttestations = val
-	b.markFieldAsDirty(previousEpochAttestations)
-	b.rebuildTrie[previousEpochAttestations] = true
+	b.markFieldAsDirty(PreviousEpochAttestations)
+	b.rebuildTrie[PreviousEpochAttestations] = true
 	return nil
 }
 
@@ -542,12 +867,36 @@ func (b *BeaconState) SetCurrentEpochAttestations(val []*pbp2p.PendingAttestatio
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[currentEpochAttestations].MinusRef()
-	b.sharedFieldReferences[currentEpochAttestations] = &reference{refs: 1}
+	b.sharedFieldReferences[CurrentEpochAttestations].MinusRef()
+	b.sharedFieldReferences[CurrentEpochAttestations] = &reference{refs: 1}
 
 	b.state.CurrentEpochAttestations = val
-	b.markFieldAsDirty(currentEpochAttestations)
-	b.rebuildTrie[currentEpochAttestations] = true
+	b.markFieldAsDirty(CurrentEpochAttestations)
+	b.rebuildTrie[CurrentEpochAttestations] = true
+	return nil
+}
+
+// RotateAttestations moves the current epoch pending attestations into the previous
+// epoch ones, and clears the current epoch pending attestations, as done on the
+// epoch boundary. The rotated list keeps its reference, so it is still shared with
+// the copies of the state without being copied.
+func (b *BeaconState) RotateAttestations() error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.sharedFieldReferences[PreviousEpochAttestations].MinusRef()
+	b.sharedFieldReferences[PreviousEpochAttestations] = b.sharedFieldReferences[CurrentEpochAttestations]
+	b.sharedFieldReferences[CurrentEpochAttestations] = &reference{refs: 1}
+
+	b.state.PreviousEpochAttestations = b.state.CurrentEpochAttestations
+	b.state.CurrentEpochAttestations = []*pbp2p.PendingAttestation{}
+	b.markFieldAsDirty(PreviousEpochAttestations)
+	b.markFieldAsDirty(CurrentEpochAttestations)
+	b.rebuildTrie[PreviousEpochAttestations] = true
+	b.rebuildTrie[CurrentEpochAttestations] = true
 	return nil
 }
 
@@ -561,20 +910,20 @@ func (b *BeaconState) AppendHistoricalRoots(root [32]byte) error {
 	defer b.lock.Unlock()
 
 	roots := b.state.HistoricalRoots
-	if b.sharedFieldReferences[historicalRoots].Refs() > 1 {
+	if b.sharedFieldReferences[HistoricalRoots].Refs() > 1 {
 		roots = make([][]byte, len(b.state.HistoricalRoots))
 		copy(roots, b.state.HistoricalRoots)
-		b.sharedFieldReferences[historicalRoots].MinusRef()
-		b.sharedFieldReferences[historicalRoots] = &reference{refs: 1}
+		b.sharedFieldReferences[HistoricalRoots].MinusRef()
+		b.sharedFieldReferences[HistoricalRoots] = &reference{refs: 1}
 	}
 
 	b.state.HistoricalRoots = append(roots, root[:])
-	b.markFieldAsDirty(historicalRoots)
+	b.markFieldAsDirty(HistoricalRoots)
 	return nil
 }
 
-// AppendCurrentEpochAttestations for the beacon state. Appends the new value
-// to the the end of list.
+// AppendCurrentEpochAttestations for the beacon state. Appends a copy of the
+// new value to the the end of list, so the caller may reuse it.
 func (b *BeaconState) AppendCurrentEpochAttestations(val *pbp2p.PendingAttestation) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -583,22 +932,22 @@ func (b *BeaconState) AppendCurrentEpochAttestations(val *pbp2p.PendingAttestati
 	defer b.lock.Unlock()
 
 	atts := b.state.CurrentEpochAttestations
-	if b.sharedFieldReferences[currentEpochAttestations].Refs() > 1 {
+	if b.sharedFieldReferences[CurrentEpochAttestations].Refs() > 1 {
 		// Copy elements in underlying array by reference.
 		atts = make([]*pbp2p.PendingAttestation, len(b.state.CurrentEpochAttestations))
 		copy(atts, b.state.CurrentEpochAttestations)
-		b.sharedFieldReferences[currentEpochAttestations].MinusRef()
-		b.sharedFieldReferences[currentEpochAttestations] = &reference{refs: 1}
+		b.sharedFieldReferences[CurrentEpochAttestations].MinusRef()
+		b.sharedFieldReferences[CurrentEpochAttestations] = &reference{refs: 1}
 	}
 
-	b.state.CurrentEpochAttestations = append(atts, val)
-	b.markFieldAsDirty(currentEpochAttestations)
-	b.dirtyIndices[currentEpochAttestations] = append(b.dirtyIndices[currentEpochAttestations], uint64(len(b.state.CurrentEpochAttestations)-1))
+	b.state.CurrentEpochAttestations = append(atts, proto.Clone(val).(*pbp2p.PendingAttestation))
+	b.markFieldAsDirty(CurrentEpochAttestations)
+	b.addDirtyIndices(CurrentEpochAttestations, []uint64{uint64(len(b.state.CurrentEpochAttestations) - 1)})
 	return nil
 }
 
-// AppendPreviousEpochAttestations for the beacon state. Appends the new value
-// to the the end of list.
+// AppendPreviousEpochAttestations for the beacon state. Appends a copy of the
+// new value to the the end of list, so the caller may reuse it.
 func (b *BeaconState) AppendPreviousEpochAttestations(val *pbp2p.PendingAttestation) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -607,16 +956,16 @@ func (b *BeaconState) AppendPreviousEpochAttestations(val *pbp2p.PendingAttestat
 	defer b.lock.Unlock()
 
 	atts := b.state.PreviousEpochAttestations
-	if b.sharedFieldReferences[previousEpochAttestations].Refs() > 1 {
+	if b.sharedFieldReferences[PreviousEpochAttestations].Refs() > 1 {
 		atts = make([]*pbp2p.PendingAttestation, len(b.state.PreviousEpochAttestations))
 		copy(atts, b.state.PreviousEpochAttestations)
-		b.sharedFieldReferences[previousEpochAttestations].MinusRef()
-		b.sharedFieldReferences[previousEpochAttestations] = &reference{refs: 1}
+		b.sharedFieldReferences[PreviousEpochAttestations].MinusRef()
+		b.sharedFieldReferences[PreviousEpochAttestations] = &reference{refs: 1}
 	}
 
-	b.state.PreviousEpochAttestations = append(atts, val)
-	b.markFieldAsDirty(previousEpochAttestations)
-	b.addDirtyIndices(previousEpochAttestations, []uint64{uint64(len(b.state.PreviousEpochAttestations) - 1)})
+	b.state.PreviousEpochAttestations = append(atts, proto.Clone(val).(*pbp2p.PendingAttestation))
+	b.markFieldAsDirty(PreviousEpochAttestations)
+	b.addDirtyIndices(PreviousEpochAttestations, []uint64{uint64(len(b.state.PreviousEpochAttestations) - 1)})
 
 	return nil
 }
@@ -631,10 +980,10 @@ func (b *BeaconState) AppendValidator(val *ethpb.Validator) error {
 	defer b.lock.Unlock()
 
 	vals := b.state.Validators
-	if b.sharedFieldReferences[validators].Refs() > 1 {
+	if b.sharedFieldReferences[Validators].Refs() > 1 {
 		vals = b.validators()
-		b.sharedFieldReferences[validators].MinusRef()
-		b.sharedFieldReferences[validators] = &reference{refs: 1}
+		b.sharedFieldReferences[Validators].MinusRef()
+		b.sharedFieldReferences[Validators] = &reference{refs: 1}
 	}
 
 	// append validator to slice
@@ -649,8 +998,8 @@ func (b *BeaconState) AppendValidator(val *ethpb.Validator) error {
 	}
 	b.valMapHandler.valIdxMap[bytesutil.ToBytes48(val.PublicKey)] = valIdx
 
-	b.markFieldAsDirty(validators)
-	b.addDirtyIndices(validators, []uint64{valIdx})
+	b.markFieldAsDirty(Validators)
+	b.addDirtyIndices(Validators, []uint64{valIdx})
 	return nil
 }
 
@@ -664,18 +1013,21 @@ func (b *BeaconState) AppendBalance(bal uint64) error {
 	defer b.lock.Unlock()
 
 	bals := b.state.Balances
-	if b.sharedFieldReferences[balances].Refs() > 1 {
+	if b.sharedFieldReferences[Balances].Refs() > 1 {
 		bals = b.balances()
-		b.sharedFieldReferences[balances].MinusRef()
-		b.sharedFieldReferences[balances] = &reference{refs: 1}
+		b.sharedFieldReferences[Balances].MinusRef()
+		b.sharedFieldReferences[Balances] = &reference{refs: 1}
 	}
 
 	b.state.Balances = append(bals, bal)
-	b.markFieldAsDirty(balances)
+	balIdx := uint64(len(b.state.Balances) - 1)
+	b.markFieldAsDirty(Balances)
+	b.addDirtyIndices(Balances, []uint64{balIdx})
 	return nil
 }
 
-// SetJustificationBits for the beacon state.
+// SetJustificationBits for the beacon state. The provided bits
+// are copied, so the caller may keep modifying them.
 func (b *BeaconState) SetJustificationBits(val bitfield.Bitvector4) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -683,12 +1035,18 @@ func (b *BeaconState) SetJustificationBits(val bitfield.Bitvector4) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.state.JustificationBits = val
-	b.markFieldAsDirty(justificationBits)
+	var bits bitfield.Bitvector4
+	if val != nil {
+		bits = make(bitfield.Bitvector4, len(val))
+		copy(bits, val)
+	}
+	b.state.JustificationBits = bits
+	b.markFieldAsDirty(JustificationBits)
 	return nil
 }
 
-// SetPreviousJustifiedCheckpoint for the beacon state.
+// SetPreviousJustifiedCheckpoint for the beacon state. The checkpoint
+// is copied rather than referenced.
 func (b *BeaconState) SetPreviousJustifiedCheckpoint(val *ethpb.Checkpoint) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -696,12 +1054,13 @@ func (b *BeaconState) SetPreviousJustifiedCheckpoint(val *ethpb.Checkpoint) erro
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.state.PreviousJustifiedCheckpoint = val
-	b.markFieldAsDirty(previousJustifiedCheckpoint)
+	b.state.PreviousJustifiedCheckpoint = CopyCheckpoint(val)
+	b.markFieldAsDirty(PreviousJustifiedCheckpoint)
 	return nil
 }
 
-// SetCurrentJustifiedCheckpoint for the beacon state.
+// SetCurrentJustifiedCheckpoint for the beacon state. The checkpoint
+// is copied rather than referenced.
 func (b *BeaconState) SetCurrentJustifiedCheckpoint(val *ethpb.Checkpoint) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -709,12 +1068,13 @@ func (b *BeaconState) SetCurrentJustifiedCheckpoint(val *ethpb.Checkpoint) error
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.state.CurrentJustifiedCheckpoint = val
-	b.markFieldAsDirty(currentJustifiedCheckpoint)
+	b.state.CurrentJustifiedCheckpoint = CopyCheckpoint(val)
+	b.markFieldAsDirty(CurrentJustifiedCheckpoint)
 	return nil
 }
 
-// SetFinalizedCheckpoint for the beacon state.
+// SetFinalizedCheckpoint for the beacon state. The checkpoint
+// is copied rather than referenced.
 func (b *BeaconState) SetFinalizedCheckpoint(val *ethpb.Checkpoint) error {
 	if !b.HasInnerState() {
 		return ErrNilInnerState
@@ -722,8 +1082,8 @@ func (b *BeaconState) SetFinalizedCheckpoint(val *ethpb.Checkpoint) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.state.FinalizedCheckpoint = val
-	b.markFieldAsDirty(finalizedCheckpoint)
+	b.state.FinalizedCheckpoint = CopyCheckpoint(val)
+	b.markFieldAsDirty(FinalizedCheckpoint)
 	return nil
 }
 
@@ -762,16 +1122,59 @@ func (b *BeaconState) recomputeRoot(idx int) {
 	b.merkleLayers = layers
 }
 
-func (b *BeaconState) markFieldAsDirty(field fieldIndex) {
+// ClearDirtyValidators resets the set of changed validators reported by
+// DirtyValidatorIndices, typically once they have been persisted.
+func (b *BeaconState) ClearDirtyValidators() {
+	if !b.HasInnerState() {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.dirtyValidators = make(map[uint64]bool)
+}
+
+// OnFieldChange registers an observer which is called with the index of every
+// field a setter modifies, such as Slot for SetSlot. Observers are called while
+// the state is locked, so they must not access the state themselves. They are
+// not carried over to copies of the state.
+func (b *BeaconState) OnFieldChange(f func(field FieldIndex)) {
+	if !b.HasInnerState() || f == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.fieldObservers = append(b.fieldObservers, f)
+}
+
+func (b *BeaconState) markFieldAsDirty(field FieldIndex) {
 	_, ok := b.dirtyFields[field]
 	if !ok {
 		b.dirtyFields[field] = true
 	}
 	// do nothing if field already exists
+	if field == Validators {
+		// The cached active indices depend on the validators.
+		b.activeIndices.clear()
+	}
+	if field == Validators || field == RandaoMixes {
+		b.proposerIndex.clear()
+	}
+	if b.fieldObservers != nil {
+		for _, f := range b.fieldObservers {
+			f(field)
+		}
+	}
 }
 
 // addDirtyIndices adds the relevant dirty field indices, so that they
 // can be recomputed.
-func (b *BeaconState) addDirtyIndices(index fieldIndex, indices []uint64) {
+func (b *BeaconState) addDirtyIndices(index FieldIndex, indices []uint64) {
 	b.dirtyIndices[index] = append(b.dirtyIndices[index], indices...)
+	if index == Validators {
+		for _, idx := range indices {
+			b.dirtyValidators[idx] = true
+		}
+	}
 }
@@ -0,0 +1,660 @@
+package state
+
+import (
+	"math"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestBeaconState_IncreaseDecreaseBalance(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{Balances: []uint64{100, 200}})
+	require.NoError(t, err)
+
+	require.NoError(t, st.IncreaseBalance(0, 50))
+	bal, err := st.BalanceAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), bal)
+
+	require.NoError(t, st.DecreaseBalance(1, 50))
+	bal, err = st.BalanceAtIndex(1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), bal)
+
+	assert.ErrorContains(t, "invalid index provided 2", st.IncreaseBalance(2, 1))
+	assert.ErrorContains(t, "invalid index provided 2", st.DecreaseBalance(2, 1))
+}
+
+func TestBeaconState_SetBalances_CopiesInput(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{Balances: []uint64{1}})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	bals := []uint64{100, 200, 300}
+	require.NoError(t, st.SetBalances(bals))
+	bals[0] = 0
+	assert.DeepEqual(t, []uint64{100, 200, 300}, st.Balances())
+	assert.DeepEqual(t, []uint64{1}, cp.Balances())
+	assert.Equal(t, true, st.rebuildTrie[Balances])
+	_, ok := st.dirtyFields[Balances]
+	assert.Equal(t, true, ok)
+}
+
+func BenchmarkBeaconState_SetBalances(b *testing.B) {
+	bals := make([]uint64, 300000)
+	for i := range bals {
+		bals[i] = uint64(i)
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, st.SetBalances(bals))
+	}
+}
+
+func TestBeaconState_ApplyBalanceDeltas(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{Balances: []uint64{100, 200, 300, 400}})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	require.NoError(t, st.ApplyBalanceDeltas([]int64{50, -50, -301, 0}))
+	assert.DeepEqual(t, []uint64{150, 150, 0, 400}, st.Balances())
+	assert.Equal(t, true, st.rebuildTrie[Balances])
+	_, ok := st.dirtyFields[Balances]
+	assert.Equal(t, true, ok)
+
+	// The copy shares the balances and must not be mutated.
+	assert.DeepEqual(t, []uint64{100, 200, 300, 400}, cp.Balances())
+
+	assert.ErrorContains(t, "deltas length 1 does not match balances length 4", st.ApplyBalanceDeltas([]int64{1}))
+
+	// Increases saturate instead of wrapping around.
+	st, err = InitializeFromProto(&pb.BeaconState{Balances: []uint64{math.MaxUint64 - 1, math.MaxUint64 - 10}})
+	require.NoError(t, err)
+	require.NoError(t, st.ApplyBalanceDeltas([]int64{math.MaxInt64, 10}))
+	assert.DeepEqual(t, []uint64{math.MaxUint64, math.MaxUint64}, st.Balances())
+}
+
+func BenchmarkBeaconState_ApplyBalanceDeltas(b *testing.B) {
+	bals := make([]uint64, 300000)
+	deltas := make([]int64, len(bals))
+	for i := range bals {
+		bals[i] = uint64(i)
+		deltas[i] = int64(i%3) - 1
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Balances: bals})
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, st.ApplyBalanceDeltas(deltas))
+	}
+}
+
+func BenchmarkBeaconState_ApplyBalanceDeltas_PerIndex(b *testing.B) {
+	bals := make([]uint64, 300000)
+	deltas := make([]int64, len(bals))
+	for i := range bals {
+		bals[i] = uint64(i)
+		deltas[i] = int64(i%3) - 1
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Balances: bals})
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for idx, delta := range deltas {
+			if delta >= 0 {
+				require.NoError(b, st.IncreaseBalance(uint64(idx), uint64(delta)))
+			} else {
+				require.NoError(b, st.DecreaseBalance(uint64(idx), uint64(-delta)))
+			}
+		}
+	}
+}
+
+func TestBeaconState_DecreaseBalance_Underflow(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{Balances: []uint64{100}})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	require.NoError(t, st.DecreaseBalance(0, 101))
+	bal, err := st.BalanceAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), bal)
+
+	// The copy shares the balances and must not be mutated.
+	bal, err = cp.BalanceAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), bal)
+}
+
+func TestBeaconState_AppendHistoricalRoots(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{HistoricalRoots: [][]byte{make([]byte, 32)}})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	root := bytesutil.ToBytes32([]byte("root"))
+	require.NoError(t, st.AppendHistoricalRoots(root))
+	assert.Equal(t, 2, st.HistoricalRootsLength())
+	assert.DeepEqual(t, root[:], st.HistoricalRoots()[1])
+	assert.Equal(t, 1, cp.HistoricalRootsLength())
+}
+
+func TestBeaconState_UpdateRandaoMixesAtIndex(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{RandaoMixes: [][]byte{make([]byte, 32), make([]byte, 32)}})
+	require.NoError(t, err)
+
+	mix := bytesutil.ToBytes32([]byte("mix"))
+	require.NoError(t, st.UpdateRandaoMixesAtIndex(1, mix))
+	got, err := st.RandaoMixAtIndex(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, mix[:], got)
+	assert.ErrorContains(t, "invalid index provided 2", st.UpdateRandaoMixesAtIndex(2, mix))
+}
+
+func TestBeaconState_UpdateBlockAndStateRootAtIndex(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		BlockRoots: [][]byte{make([]byte, 32), make([]byte, 32)},
+		StateRoots: [][]byte{make([]byte, 32), make([]byte, 32)},
+	})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	blockRoot := bytesutil.ToBytes32([]byte("block"))
+	stateRoot := bytesutil.ToBytes32([]byte("state"))
+	require.NoError(t, st.UpdateBlockRootAtIndex(1, blockRoot))
+	require.NoError(t, st.UpdateStateRootAtIndex(1, stateRoot))
+
+	got, err := st.BlockRootAtIndex(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, blockRoot[:], got)
+	got, err = st.StateRootAtIndex(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, stateRoot[:], got)
+
+	// Only the updated state is mutated.
+	got, err = cp.BlockRootAtIndex(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, make([]byte, 32), got)
+	got, err = cp.StateRootAtIndex(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, make([]byte, 32), got)
+
+	assert.ErrorContains(t, "invalid index provided 2", st.UpdateBlockRootAtIndex(2, blockRoot))
+	assert.ErrorContains(t, "invalid index provided 2", st.UpdateStateRootAtIndex(2, stateRoot))
+}
+
+func TestBeaconState_SetEth1Data(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+
+	data := &ethpb.Eth1Data{
+		DepositRoot:  bytesutil.PadTo([]byte("root"), 32),
+		DepositCount: 5,
+		BlockHash:    bytesutil.PadTo([]byte("hash"), 32),
+	}
+	require.NoError(t, st.SetEth1Data(data))
+	_, ok := st.dirtyFields[Eth1Data]
+	assert.Equal(t, true, ok)
+	data.BlockHash[0] = 'x'
+	assert.DeepEqual(t, bytesutil.PadTo([]byte("hash"), 32), st.Eth1Data().BlockHash)
+
+	err = st.SetEth1Data(&ethpb.Eth1Data{
+		DepositRoot: bytesutil.PadTo([]byte("root"), 32),
+		BlockHash:   []byte("hash"),
+	})
+	assert.ErrorContains(t, "eth1 data block hash must be 32 bytes, received 4", err)
+	err = st.SetEth1Data(&ethpb.Eth1Data{DepositRoot: []byte("root")})
+	assert.ErrorContains(t, "eth1 data deposit root must be 32 bytes, received 4", err)
+	assert.ErrorContains(t, "nil eth1 data", st.SetEth1Data(nil))
+	assert.Equal(t, uint64(5), st.Eth1Data().DepositCount)
+}
+
+func TestBeaconState_LatestBlockHeaderRoundTrip(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+
+	hdr := &ethpb.BeaconBlockHeader{
+		Slot:          3,
+		ProposerIndex: 7,
+		ParentRoot:    bytesutil.PadTo([]byte("parent"), 32),
+		StateRoot:     bytesutil.PadTo([]byte("state"), 32),
+		BodyRoot:      bytesutil.PadTo([]byte("body"), 32),
+	}
+	require.NoError(t, st.SetLatestBlockHeader(hdr))
+	got := st.LatestBlockHeaderCopy()
+	assert.DeepEqual(t, hdr, got)
+
+	// The returned header is detached from the state.
+	got.StateRoot[0] = 'x'
+	assert.DeepEqual(t, bytesutil.PadTo([]byte("state"), 32), st.LatestBlockHeaderCopy().StateRoot)
+	require.NoError(t, st.SetLatestBlockHeader(got))
+	assert.DeepEqual(t, got, st.LatestBlockHeaderCopy())
+
+	hdr.BodyRoot = []byte("body")
+	assert.ErrorContains(t, "block header body root must be 32 bytes, received 4", st.SetLatestBlockHeader(hdr))
+	assert.ErrorContains(t, "nil block header", st.SetLatestBlockHeader(nil))
+}
+
+func TestBeaconState_SetHistoricalRoots(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+
+	roots := [][]byte{bytesutil.PadTo([]byte("a"), 32), bytesutil.PadTo([]byte("b"), 32)}
+	require.NoError(t, st.SetHistoricalRoots(roots))
+	_, ok := st.dirtyFields[HistoricalRoots]
+	assert.Equal(t, true, ok)
+	roots[0][0] = 'x'
+	assert.DeepEqual(t, [][]byte{bytesutil.PadTo([]byte("a"), 32), bytesutil.PadTo([]byte("b"), 32)}, st.HistoricalRoots())
+
+	err = st.SetHistoricalRoots([][]byte{make([]byte, 32), make([]byte, 31)})
+	assert.ErrorContains(t, "historical root at index 1 must be 32 bytes, received 31", err)
+	assert.Equal(t, 2, len(st.HistoricalRoots()))
+}
+
+func TestBeaconState_SetEth1DataVotes_ClearThenAppend(t *testing.T) {
+	vote := func(count uint64) *ethpb.Eth1Data {
+		return &ethpb.Eth1Data{
+			DepositRoot:  make([]byte, 32),
+			DepositCount: count,
+			BlockHash:    make([]byte, 32),
+		}
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Eth1DataVotes: []*ethpb.Eth1Data{vote(1), vote(2)}})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	require.NoError(t, st.SetEth1DataVotes([]*ethpb.Eth1Data{}))
+	assert.Equal(t, 0, len(st.Eth1DataVotes()))
+	assert.Equal(t, 2, len(cp.Eth1DataVotes()))
+	_, ok := st.dirtyFields[Eth1DataVotes]
+	assert.Equal(t, true, ok, "Expected votes to be marked as dirty")
+	assert.Equal(t, true, st.rebuildTrie[Eth1DataVotes], "Expected votes trie to be rebuilt")
+
+	require.NoError(t, st.AppendEth1DataVotes(vote(3)))
+	assert.DeepEqual(t, []*ethpb.Eth1Data{vote(3)}, st.Eth1DataVotes())
+
+	// Mutating the input after setting it must not affect the state.
+	votes := []*ethpb.Eth1Data{vote(4)}
+	require.NoError(t, st.SetEth1DataVotes(votes))
+	votes[0].DepositCount = 5
+	assert.Equal(t, uint64(4), st.Eth1DataVotes()[0].DepositCount)
+}
+
+func TestBeaconState_UpdateEth1DataVotesAtIndex(t *testing.T) {
+	vote := func(count uint64) *ethpb.Eth1Data {
+		return &ethpb.Eth1Data{
+			DepositRoot:  make([]byte, 32),
+			DepositCount: count,
+			BlockHash:    make([]byte, 32),
+		}
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Eth1DataVotes: []*ethpb.Eth1Data{vote(1), vote(2)}})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	updated := vote(3)
+	require.NoError(t, st.UpdateEth1DataVotesAtIndex(0, updated))
+	updated.DepositCount = 4
+	assert.DeepEqual(t, []*ethpb.Eth1Data{vote(3), vote(2)}, st.Eth1DataVotes())
+	assert.DeepEqual(t, []uint64{0}, st.dirtyIndices[Eth1DataVotes])
+
+	// The copy shares the votes and must not be mutated.
+	assert.DeepEqual(t, []*ethpb.Eth1Data{vote(1), vote(2)}, cp.Eth1DataVotes())
+
+	assert.ErrorContains(t, "invalid index provided 2", st.UpdateEth1DataVotesAtIndex(2, vote(5)))
+	assert.ErrorContains(t, "nil eth1 data", st.UpdateEth1DataVotesAtIndex(0, nil))
+}
+
+func TestBeaconState_AppendEth1DataVotes(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+
+	votes := make([]*ethpb.Eth1Data, 3)
+	for i := range votes {
+		votes[i] = &ethpb.Eth1Data{
+			DepositRoot:  bytesutil.PadTo([]byte{byte(i)}, 32),
+			DepositCount: uint64(i),
+			BlockHash:    bytesutil.PadTo([]byte{byte(i + 10)}, 32),
+		}
+		require.NoError(t, st.AppendEth1DataVotes(votes[i]))
+	}
+	assert.DeepEqual(t, votes, st.Eth1DataVotes())
+	assert.DeepEqual(t, []uint64{0, 1, 2}, st.dirtyIndices[Eth1DataVotes])
+
+	// Mutating an appended vote must not affect the state.
+	votes[1].DepositCount = 100
+	votes[1].BlockHash[0] = 0xff
+	got := st.Eth1DataVotes()[1]
+	assert.Equal(t, uint64(1), got.DepositCount)
+	assert.Equal(t, byte(11), got.BlockHash[0])
+}
+
+func TestBeaconState_SetValidators(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*ethpb.Validator{{PublicKey: bytesutil.PadTo([]byte{1}, 48)}},
+	})
+	require.NoError(t, err)
+
+	vals := []*ethpb.Validator{
+		{PublicKey: bytesutil.PadTo([]byte{2}, 48), EffectiveBalance: 2},
+		{PublicKey: bytesutil.PadTo([]byte{3}, 48), EffectiveBalance: 3},
+	}
+	require.NoError(t, st.SetValidators(vals))
+	assert.Equal(t, 2, st.NumValidators())
+
+	_, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(bytesutil.PadTo([]byte{1}, 48)))
+	assert.Equal(t, false, ok, "Expected replaced validator to be removed from the index")
+	for i, v := range vals {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(v.PublicKey))
+		require.Equal(t, true, ok)
+		assert.Equal(t, uint64(i), idx)
+	}
+
+	// Mutating the input after setting it must not affect the state.
+	vals[0].EffectiveBalance = 100
+	v, err := st.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), v.EffectiveBalance)
+}
+
+func TestBeaconState_SetValidatorActivation(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*ethpb.Validator{{EffectiveBalance: 1}, {EffectiveBalance: 2}},
+	})
+	require.NoError(t, err)
+	copied := st.Copy()
+	st.dirtyIndices[Validators] = []uint64{}
+
+	require.NoError(t, st.SetValidatorActivationEligibility(1, 5))
+	require.NoError(t, st.SetValidatorActivationEpoch(1, 9))
+	val, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), val.ActivationEligibilityEpoch)
+	assert.Equal(t, uint64(9), val.ActivationEpoch)
+	assert.Equal(t, uint64(2), val.EffectiveBalance)
+	assert.DeepEqual(t, []uint64{1, 1}, st.dirtyIndices[Validators])
+
+	// The copy shared the registry and is unaffected.
+	val, err = copied.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), val.ActivationEligibilityEpoch)
+	assert.Equal(t, uint64(0), val.ActivationEpoch)
+
+	assert.ErrorContains(t, "invalid index provided 2", st.SetValidatorActivationEligibility(2, 5))
+	assert.ErrorContains(t, "invalid index provided 2", st.SetValidatorActivationEpoch(2, 9))
+}
+
+func TestBeaconState_SetValidatorSlashed(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*ethpb.Validator{{EffectiveBalance: 1}, {EffectiveBalance: 2}},
+	})
+	require.NoError(t, err)
+	copied := st.Copy()
+	st.dirtyIndices[Validators] = []uint64{}
+
+	require.NoError(t, st.SetValidatorSlashed(0))
+	require.NoError(t, st.SetValidatorExitEpoch(0, 10))
+	require.NoError(t, st.SetValidatorWithdrawableEpoch(0, 20))
+	val, err := st.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, true, val.Slashed)
+	assert.Equal(t, uint64(10), val.ExitEpoch)
+	assert.Equal(t, uint64(20), val.WithdrawableEpoch)
+	assert.Equal(t, uint64(1), val.EffectiveBalance)
+	assert.DeepEqual(t, []uint64{0, 0, 0}, st.dirtyIndices[Validators])
+
+	// The copy shared the registry and is unaffected.
+	val, err = copied.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, false, val.Slashed)
+	assert.Equal(t, uint64(0), val.ExitEpoch)
+	assert.Equal(t, uint64(0), val.WithdrawableEpoch)
+
+	assert.ErrorContains(t, "invalid index provided 2", st.SetValidatorSlashed(2))
+	assert.ErrorContains(t, "invalid index provided 2", st.SetValidatorExitEpoch(2, 10))
+	assert.ErrorContains(t, "invalid index provided 2", st.SetValidatorWithdrawableEpoch(2, 20))
+}
+
+func TestBeaconState_DirtyValidatorIndices(t *testing.T) {
+	vals := make([]*ethpb.Validator, 5)
+	for i := range vals {
+		vals[i] = &ethpb.Validator{PublicKey: bytesutil.PadTo([]byte{byte(i)}, 48)}
+	}
+	st, err := InitializeFromProto(&pb.BeaconState{Validators: vals})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(st.DirtyValidatorIndices()))
+
+	require.NoError(t, st.UpdateValidatorAtIndex(3, &ethpb.Validator{PublicKey: vals[3].PublicKey, Slashed: true}))
+	require.NoError(t, st.ApplyToEveryValidator(func(idx int, val *ethpb.Validator) (bool, error) {
+		if idx != 1 {
+			return false, nil
+		}
+		val.EffectiveBalance = 1
+		return true, nil
+	}))
+	assert.DeepEqual(t, []uint64{1, 3}, st.DirtyValidatorIndices())
+
+	assert.DeepEqual(t, []uint64{1, 3}, st.Copy().DirtyValidatorIndices())
+
+	st.ClearDirtyValidators()
+	assert.Equal(t, 0, len(st.DirtyValidatorIndices()))
+}
+
+func TestBeaconState_UpdateSlashingsAtIndex(t *testing.T) {
+	slashings := []uint64{10, 20, 30}
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlashings(slashings))
+	slashings[0] = 100
+	got, err := st.SlashingAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), got)
+
+	copied := st.Copy()
+	st.dirtyFields = make(map[FieldIndex]interface{})
+	require.NoError(t, st.UpdateSlashingsAtIndex(1, 0))
+	got, err = st.SlashingAtIndex(1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), got)
+	_, ok := st.dirtyFields[Slashings]
+	assert.Equal(t, true, ok, "Expected slashings to be marked as dirty")
+	_, ok = st.dirtyFields[Balances]
+	assert.Equal(t, false, ok, "Expected only the slashings to be marked as dirty")
+
+	// The copy shared the slashings and is unaffected.
+	got, err = copied.SlashingAtIndex(1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), got)
+
+	assert.ErrorContains(t, "invalid index provided 3", st.UpdateSlashingsAtIndex(3, 0))
+	_, err = st.SlashingAtIndex(3)
+	assert.ErrorContains(t, "index 3 out of range", err)
+}
+
+func TestBeaconState_OnFieldChange(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{Balances: []uint64{1}})
+	require.NoError(t, err)
+	var changed []FieldIndex
+	st.OnFieldChange(func(field FieldIndex) {
+		changed = append(changed, field)
+	})
+
+	require.NoError(t, st.SetSlot(3))
+	require.NoError(t, st.UpdateBalancesAtIndex(0, 2))
+	assert.DeepEqual(t, []FieldIndex{Slot, Balances}, changed)
+
+	// Copies of the state are not observed.
+	require.NoError(t, st.Copy().SetSlot(4))
+	assert.Equal(t, 2, len(changed))
+}
+
+func TestBeaconState_AdvanceSlot(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{Slot: 5})
+	require.NoError(t, err)
+
+	require.NoError(t, st.AdvanceSlot())
+	assert.Equal(t, uint64(6), st.Slot())
+	_, ok := st.dirtyFields[Slot]
+	assert.Equal(t, true, ok, "Expected slot to be marked as dirty")
+
+	require.NoError(t, st.SetSlot(math.MaxUint64))
+	assert.ErrorContains(t, "slot overflow", st.AdvanceSlot())
+	assert.Equal(t, uint64(math.MaxUint64), st.Slot())
+}
+
+func TestBeaconState_SetJustificationBits(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{JustificationBits: bitfield.Bitvector4{0b0001}})
+	require.NoError(t, err)
+
+	// Shift the bits as done during justification processing and mark the current epoch.
+	bits := st.JustificationBits()
+	bits.Shift(1)
+	bits.SetBitAt(0, true)
+	require.NoError(t, st.SetJustificationBits(bits))
+	_, ok := st.dirtyFields[JustificationBits]
+	assert.Equal(t, true, ok, "Expected justification bits to be marked as dirty")
+
+	for i, want := range []bool{true, true, false, false} {
+		got, err := st.JustificationBitAtIndex(uint64(i))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "Unexpected bit at index %d", i)
+	}
+	_, err = st.JustificationBitAtIndex(4)
+	assert.ErrorContains(t, "index 4 out of range", err)
+
+	// Mutating the input after setting it must not affect the state.
+	bits.SetBitAt(3, true)
+	got, err := st.JustificationBitAtIndex(3)
+	require.NoError(t, err)
+	assert.Equal(t, false, got)
+}
+
+func TestBeaconState_SetCheckpoints(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+
+	prev := &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("prev"), 32)}
+	curr := &ethpb.Checkpoint{Epoch: 2, Root: bytesutil.PadTo([]byte("curr"), 32)}
+	fin := &ethpb.Checkpoint{Epoch: 3, Root: bytesutil.PadTo([]byte("fin"), 32)}
+	require.NoError(t, st.SetPreviousJustifiedCheckpoint(prev))
+	require.NoError(t, st.SetCurrentJustifiedCheckpoint(curr))
+	require.NoError(t, st.SetFinalizedCheckpoint(fin))
+
+	_, ok := st.dirtyFields[PreviousJustifiedCheckpoint]
+	assert.Equal(t, true, ok)
+	_, ok = st.dirtyFields[CurrentJustifiedCheckpoint]
+	assert.Equal(t, true, ok)
+	_, ok = st.dirtyFields[FinalizedCheckpoint]
+	assert.Equal(t, true, ok)
+	_, ok = st.dirtyFields[Validators]
+	assert.Equal(t, false, ok)
+
+	wantPrev := CopyCheckpoint(prev)
+	wantCurr := CopyCheckpoint(curr)
+	wantFin := CopyCheckpoint(fin)
+	prev.Epoch = 100
+	curr.Root[0] = 'x'
+	fin.Epoch = 100
+	assert.DeepEqual(t, wantPrev, st.PreviousJustifiedCheckpoint())
+	assert.DeepEqual(t, wantCurr, st.CurrentJustifiedCheckpoint())
+	assert.DeepEqual(t, wantFin, st.FinalizedCheckpoint())
+}
+
+func TestBeaconState_RecomputeEffectiveBalances(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{
+		Validators: []*ethpb.Validator{
+			{EffectiveBalance: 32e9},
+			{EffectiveBalance: 32e9},
+			{EffectiveBalance: 31e9},
+			{EffectiveBalance: 31e9},
+		},
+		// The hysteresis thresholds are 0.25 ETH downwards and 1.25 ETH upwards.
+		Balances: []uint64{31.8e9, 31.7e9, 32.3e9, 32.2e9},
+	})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	require.NoError(t, st.RecomputeEffectiveBalances())
+	var effBalances []uint64
+	for i := 0; i < st.NumValidators(); i++ {
+		val, err := st.ValidatorAtIndexReadOnly(uint64(i))
+		require.NoError(t, err)
+		effBalances = append(effBalances, val.EffectiveBalance())
+	}
+	assert.DeepEqual(t, []uint64{32e9, 31e9, 32e9, 31e9}, effBalances)
+	assert.DeepEqual(t, []uint64{1, 2}, st.dirtyIndices[Validators])
+
+	// The copy still holds the previous effective balances.
+	val, err := cp.ValidatorAtIndexReadOnly(1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(32e9), val.EffectiveBalance())
+
+	// Nothing is copied when no effective balance changes.
+	cp = st.Copy()
+	require.NoError(t, st.RecomputeEffectiveBalances())
+	assert.Equal(t, uint(2), st.sharedFieldReferences[Validators].Refs())
+}
+
+func TestBeaconState_RotateAttestations(t *testing.T) {
+	curr := []*pb.PendingAttestation{{InclusionDelay: 3}, {InclusionDelay: 4}}
+	st, err := InitializeFromProto(&pb.BeaconState{
+		PreviousEpochAttestations: []*pb.PendingAttestation{{InclusionDelay: 1}},
+		CurrentEpochAttestations:  curr,
+	})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	require.NoError(t, st.RotateAttestations())
+	assert.DeepEqual(t, curr, st.PreviousEpochAttestations())
+	assert.Equal(t, 0, len(st.CurrentEpochAttestations()))
+	assert.Equal(t, true, st.rebuildTrie[PreviousEpochAttestations])
+	assert.Equal(t, true, st.rebuildTrie[CurrentEpochAttestations])
+
+	// The rotated list is still shared with the copy, which is left untouched.
+	assert.Equal(t, uint(2), st.sharedFieldReferences[PreviousEpochAttestations].Refs())
+	assert.Equal(t, uint(1), st.sharedFieldReferences[CurrentEpochAttestations].Refs())
+	assert.DeepEqual(t, curr, cp.CurrentEpochAttestations())
+	assert.Equal(t, 1, len(cp.PreviousEpochAttestations()))
+}
+
+func TestBeaconState_AppendEpochAttestations(t *testing.T) {
+	st, err := InitializeFromProto(&pb.BeaconState{})
+	require.NoError(t, err)
+	cp := st.Copy()
+
+	att := &pb.PendingAttestation{}
+	for i := uint64(1); i <= 3; i++ {
+		att.InclusionDelay = i
+		require.NoError(t, st.AppendCurrentEpochAttestations(att))
+		att.InclusionDelay = 10 * i
+		require.NoError(t, st.AppendPreviousEpochAttestations(att))
+	}
+	assert.DeepEqual(t, []uint64{0, 1, 2}, st.dirtyIndices[CurrentEpochAttestations])
+	assert.DeepEqual(t, []uint64{0, 1, 2}, st.dirtyIndices[PreviousEpochAttestations])
+
+	// Each appended attestation is a copy, unaffected by later changes to the input.
+	var delays []uint64
+	require.NoError(t, st.ReadFromEveryCurrentAttestation(func(_ int, att *pb.PendingAttestation) error {
+		delays = append(delays, att.InclusionDelay)
+		return nil
+	}))
+	require.NoError(t, st.ReadFromEveryPreviousAttestation(func(_ int, att *pb.PendingAttestation) error {
+		delays = append(delays, att.InclusionDelay)
+		return nil
+	}))
+	assert.DeepEqual(t, []uint64{1, 2, 3, 10, 20, 30}, delays)
+	assert.Equal(t, 0, len(cp.CurrentEpochAttestations()))
+	assert.Equal(t, 0, len(cp.PreviousEpochAttestations()))
+}
@@ -0,0 +1,66 @@
+package state
+
+import (
+	"runtime"
+)
+
+// StateSnapshot is a point in time view of a beacon state, which can be used
+// to roll the state back to that point, such as after a failed state transition.
+// A snapshot shares the fields of the state through their copy on write references,
+// so taking one is cheap and later mutations of the state do not affect it.
+type StateSnapshot struct {
+	state *BeaconState
+}
+
+// Snapshot captures the current beacon state, to be restored with Restore.
+func (b *BeaconState) Snapshot() *StateSnapshot {
+	return &StateSnapshot{state: b.Copy()}
+}
+
+// Restore rolls the beacon state back to the given snapshot, discarding any
+// mutation made since. The snapshot is left untouched, so it may be restored
+// again. Field observers registered on the state are kept.
+func (b *BeaconState) Restore(s *StateSnapshot) {
+	if s == nil || s.state == nil {
+		return
+	}
+	restored := s.state.Copy()
+	// The references of the restored copy are handed over to this state, so they
+	// must not be released once the copy is garbage collected.
+	runtime.SetFinalizer(restored, nil)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.replaceWith(restored)
+}
+
+// replaceWith swaps the inner state and all the derived caches of the beacon state
+// for the ones of src, releasing the references held on the previous fields. The
+// references of src are handed over, so src must not be used afterwards. Field
+// observers registered on the state are kept.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) replaceWith(src *BeaconState) {
+	for field, ref := range b.sharedFieldReferences {
+		ref.MinusRef()
+		if b.stateFieldLeaves[field] != nil && b.stateFieldLeaves[field].reference != nil {
+			b.stateFieldLeaves[field].MinusRef()
+		}
+	}
+	if b.valMapHandler != nil && b.valMapHandler.mapRef != nil {
+		b.valMapHandler.mapRef.MinusRef()
+	}
+
+	b.state = src.state
+	b.dirtyFields = src.dirtyFields
+	b.dirtyIndices = src.dirtyIndices
+	b.stateFieldLeaves = src.stateFieldLeaves
+	b.rebuildTrie = src.rebuildTrie
+	b.valMapHandler = src.valMapHandler
+	b.merkleLayers = src.merkleLayers
+	b.sharedFieldReferences = src.sharedFieldReferences
+	b.dirtyValidators = src.dirtyValidators
+	b.activeIndices = src.activeIndices
+	b.proposerIndex = src.proposerIndex
+	b.version = src.version
+}
@@ -0,0 +1,48 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestBeaconState_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	want := st.Copy()
+	wantRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	mutate := func() {
+		require.NoError(t, st.SetSlot(st.Slot()+10))
+		require.NoError(t, st.UpdateBalancesAtIndex(3, 1))
+		require.NoError(t, st.SetValidatorSlashed(5))
+		require.NoError(t, st.UpdateRandaoMixesAtIndex(0, bytesutil.ToBytes32([]byte("mix"))))
+		require.NoError(t, st.SetFork(&pbp2p.Fork{PreviousVersion: []byte{1, 1, 1, 1}, CurrentVersion: []byte{2, 2, 2, 2}}))
+		_, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+	}
+
+	snapshot := st.Snapshot()
+	mutate()
+	assert.Equal(t, false, st.EqualsIgnoringCache(want))
+
+	st.Restore(snapshot)
+	assert.Equal(t, true, st.EqualsIgnoringCache(want))
+	root, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, root)
+
+	// Mutating the restored state does not corrupt the snapshot.
+	mutate()
+	st.Restore(snapshot)
+	assert.Equal(t, true, st.EqualsIgnoringCache(want))
+	root, err = st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, root)
+}
@@ -23,6 +23,17 @@ func InitializeFromProto(st *pbp2p.BeaconState) (*BeaconState, error) {
 	return InitializeFromProtoUnsafe(proto.Clone(st).(*pbp2p.BeaconState))
 }
 
+// InitializeFromProtoChecked the beacon state from a protobuf representation, first
+// validating that all the required sub-messages of the state are present. Unlike
+// InitializeFromProto, which accepts partially populated states, it returns a
+// descriptive error for a state missing any of them.
+func InitializeFromProtoChecked(st *pbp2p.BeaconState) (*BeaconState, error) {
+	if err := validateRequiredFields(st); err != nil {
+		return nil, err
+	}
+	return InitializeFromProto(st)
+}
+
 // InitializeFromProtoUnsafe directly uses the beacon state protobuf pointer
 // and sets it as the inner state of the BeaconState type.
 func InitializeFromProtoUnsafe(st *pbp2p.BeaconState) (*BeaconState, error) {
@@ -33,41 +44,124 @@ func InitializeFromProtoUnsafe(st *pbp2p.BeaconState) (*BeaconState, error) {
 	fieldCount := params.BeaconConfig().BeaconStateFieldCount
 	b := &BeaconState{
 		state:                 st,
-		dirtyFields:           make(map[fieldIndex]interface{}, fieldCount),
-		dirtyIndices:          make(map[fieldIndex][]uint64, fieldCount),
-		stateFieldLeaves:      make(map[fieldIndex]*FieldTrie, fieldCount),
-		sharedFieldReferences: make(map[fieldIndex]*reference, 10),
-		rebuildTrie:           make(map[fieldIndex]bool, fieldCount),
+		dirtyFields:           make(map[FieldIndex]interface{}, fieldCount),
+		dirtyIndices:          make(map[FieldIndex][]uint64, fieldCount),
+		stateFieldLeaves:      make(map[FieldIndex]*FieldTrie, fieldCount),
+		sharedFieldReferences: make(map[FieldIndex]*reference, 10),
+		rebuildTrie:           make(map[FieldIndex]bool, fieldCount),
 		valMapHandler:         newValHandler(st.Validators),
+		dirtyValidators:       make(map[uint64]bool),
+		activeIndices:         newActiveIndicesCache(),
+		proposerIndex:         &proposerIndexCache{},
+		version:               Phase0,
 	}
 
 	for i := 0; i < fieldCount; i++ {
-		b.dirtyFields[fieldIndex(i)] = true
-		b.rebuildTrie[fieldIndex(i)] = true
-		b.dirtyIndices[fieldIndex(i)] = []uint64{}
-		b.stateFieldLeaves[fieldIndex(i)] = &FieldTrie{
-			field:     fieldIndex(i),
+		b.dirtyFields[FieldIndex(i)] = true
+		b.rebuildTrie[FieldIndex(i)] = true
+		b.dirtyIndices[FieldIndex(i)] = []uint64{}
+		b.stateFieldLeaves[FieldIndex(i)] = &FieldTrie{
+			field:     FieldIndex(i),
 			reference: &reference{refs: 1},
 			Mutex:     new(sync.Mutex),
 		}
 	}
 
 	// Initialize field reference tracking for shared data.
-	b.sharedFieldReferences[randaoMixes] = &reference{refs: 1}
-	b.sharedFieldReferences[stateRoots] = &reference{refs: 1}
-	b.sharedFieldReferences[blockRoots] = &reference{refs: 1}
-	b.sharedFieldReferences[previousEpochAttestations] = &reference{refs: 1}
-	b.sharedFieldReferences[currentEpochAttestations] = &reference{refs: 1}
-	b.sharedFieldReferences[slashings] = &reference{refs: 1}
-	b.sharedFieldReferences[eth1DataVotes] = &reference{refs: 1}
-	b.sharedFieldReferences[validators] = &reference{refs: 1}
-	b.sharedFieldReferences[balances] = &reference{refs: 1}
-	b.sharedFieldReferences[historicalRoots] = &reference{refs: 1}
+	b.sharedFieldReferences[RandaoMixes] = &reference{refs: 1}
+	b.sharedFieldReferences[StateRoots] = &reference{refs: 1}
+	b.sharedFieldReferences[BlockRoots] = &reference{refs: 1}
+	b.sharedFieldReferences[PreviousEpochAttestations] = &reference{refs: 1}
+	b.sharedFieldReferences[CurrentEpochAttestations] = &reference{refs: 1}
+	b.sharedFieldReferences[Slashings] = &reference{refs: 1}
+	b.sharedFieldReferences[Eth1DataVotes] = &reference{refs: 1}
+	b.sharedFieldReferences[Validators] = &reference{refs: 1}
+	b.sharedFieldReferences[Balances] = &reference{refs: 1}
+	b.sharedFieldReferences[HistoricalRoots] = &reference{refs: 1}
 
 	return b, nil
 }
 
-// Copy returns a deep copy of the beacon state.
+// UnmarshalSSZ decodes the SSZ encoded beacon state into the state, replacing its
+// content. The pubkey to index map and the active indices and proposer caches are
+// rebuilt from the decoded validators, and every field is marked as dirty so that
+// the field tries and the merkle layers are recomputed from the decoded state. The
+// state is left untouched if the encoding is invalid.
+func (b *BeaconState) UnmarshalSSZ(buf []byte) error {
+	st := &pbp2p.BeaconState{}
+	if err := st.UnmarshalSSZ(buf); err != nil {
+		return errors.Wrap(err, "could not unmarshal beacon state")
+	}
+	decoded, err := InitializeFromProtoUnsafe(st)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.replaceWith(decoded)
+	return nil
+}
+
+// validateRequiredFields checks that the sub-messages of the beacon state
+// protobuf which are dereferenced during state processing are non-nil.
+func validateRequiredFields(st *pbp2p.BeaconState) error {
+	if st == nil {
+		return errors.New("received nil state")
+	}
+	switch {
+	case st.Fork == nil:
+		return errors.New("state is missing fork")
+	case st.LatestBlockHeader == nil:
+		return errors.New("state is missing latest block header")
+	case st.Eth1Data == nil:
+		return errors.New("state is missing eth1 data")
+	case st.PreviousJustifiedCheckpoint == nil:
+		return errors.New("state is missing previous justified checkpoint")
+	case st.CurrentJustifiedCheckpoint == nil:
+		return errors.New("state is missing current justified checkpoint")
+	case st.FinalizedCheckpoint == nil:
+		return errors.New("state is missing finalized checkpoint")
+	}
+	return nil
+}
+
+// Validate checks the beacon state against the invariants of the spec on the lengths
+// of its vectors, which is helpful when debugging bad states. It returns an error
+// describing the first violated invariant.
+func (b *BeaconState) Validate() error {
+	if !b.HasInnerState() {
+		return ErrNilInnerState
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	cfg := params.BeaconConfig()
+	switch {
+	case uint64(len(b.state.BlockRoots)) != cfg.SlotsPerHistoricalRoot:
+		return errors.Errorf("block roots length %d does not match SLOTS_PER_HISTORICAL_ROOT %d",
+			len(b.state.BlockRoots), cfg.SlotsPerHistoricalRoot)
+	case uint64(len(b.state.StateRoots)) != cfg.SlotsPerHistoricalRoot:
+		return errors.Errorf("state roots length %d does not match SLOTS_PER_HISTORICAL_ROOT %d",
+			len(b.state.StateRoots), cfg.SlotsPerHistoricalRoot)
+	case uint64(len(b.state.RandaoMixes)) != cfg.EpochsPerHistoricalVector:
+		return errors.Errorf("randao mixes length %d does not match EPOCHS_PER_HISTORICAL_VECTOR %d",
+			len(b.state.RandaoMixes), cfg.EpochsPerHistoricalVector)
+	case uint64(len(b.state.Slashings)) != cfg.EpochsPerSlashingsVector:
+		return errors.Errorf("slashings length %d does not match EPOCHS_PER_SLASHINGS_VECTOR %d",
+			len(b.state.Slashings), cfg.EpochsPerSlashingsVector)
+	case len(b.state.Balances) != len(b.state.Validators):
+		return errors.Errorf("balances length %d does not match validators length %d",
+			len(b.state.Balances), len(b.state.Validators))
+	}
+	return nil
+}
+
+// Copy returns a copy of the beacon state which shares the backing arrays of its
+// large fields with the original under reference counting. A shared field is only
+// copied once either of the states mutates it. Use CloneInnerState instead when a
+// fully independent protobuf representation of the state is required.
 func (b *BeaconState) Copy() *BeaconState {
 	if !b.HasInnerState() {
 		return nil
@@ -107,11 +201,15 @@ func (b *BeaconState) Copy() *BeaconState {
 			FinalizedCheckpoint:         b.finalizedCheckpoint(),
 			GenesisValidatorsRoot:       b.genesisValidatorRoot(),
 		},
-		dirtyFields:           make(map[fieldIndex]interface{}, fieldCount),
-		dirtyIndices:          make(map[fieldIndex][]uint64, fieldCount),
-		rebuildTrie:           make(map[fieldIndex]bool, fieldCount),
-		sharedFieldReferences: make(map[fieldIndex]*reference, 10),
-		stateFieldLeaves:      make(map[fieldIndex]*FieldTrie, fieldCount),
+		dirtyFields:           make(map[FieldIndex]interface{}, fieldCount),
+		dirtyIndices:          make(map[FieldIndex][]uint64, fieldCount),
+		rebuildTrie:           make(map[FieldIndex]bool, fieldCount),
+		sharedFieldReferences: make(map[FieldIndex]*reference, 10),
+		stateFieldLeaves:      make(map[FieldIndex]*FieldTrie, fieldCount),
+		dirtyValidators:       make(map[uint64]bool, len(b.dirtyValidators)),
+		activeIndices:         newActiveIndicesCache(),
+		proposerIndex:         &proposerIndexCache{},
+		version:               b.version,
 
 		// Copy on write validator index map.
 		valMapHandler: b.valMapHandler,
@@ -139,6 +237,10 @@ func (b *BeaconState) Copy() *BeaconState {
 		dst.rebuildTrie[i] = true
 	}
 
+	for idx := range b.dirtyValidators {
+		dst.dirtyValidators[idx] = true
+	}
+
 	for fldIdx, fieldTrie := range b.stateFieldLeaves {
 		dst.stateFieldLeaves[fldIdx] = fieldTrie
 		if fieldTrie.reference != nil {
@@ -172,6 +274,25 @@ func (b *BeaconState) Copy() *BeaconState {
 	return dst
 }
 
+// EqualsIgnoringCache returns true if both beacon states hold the same data. Only
+// the fields of the underlying protobuf states are compared, so states differing
+// solely in their merkle layers, field tries, dirty fields or shared references,
+// such as a state and its copy, are considered equal.
+func (b *BeaconState) EqualsIgnoringCache(other *BeaconState) bool {
+	if !b.HasInnerState() || !other.HasInnerState() {
+		return !b.HasInnerState() && !other.HasInnerState()
+	}
+	if b == other {
+		return true
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+
+	return proto.Equal(b.state, other.state)
+}
+
 // HashTreeRoot of the beacon state retrieves the Merkle root of the trie
 // representation of the beacon state based on the eth2 Simple Serialize specification.
 func (b *BeaconState) HashTreeRoot(ctx context.Context) ([32]byte, error) {
@@ -188,7 +309,7 @@ func (b *BeaconState) HashTreeRoot(ctx context.Context) ([32]byte, error) {
 		}
 		layers := merkleize(fieldRoots)
 		b.merkleLayers = layers
-		b.dirtyFields = make(map[fieldIndex]interface{}, params.BeaconConfig().BeaconStateFieldCount)
+		b.dirtyFields = make(map[FieldIndex]interface{}, params.BeaconConfig().BeaconStateFieldCount)
 	}
 
 	for field := range b.dirtyFields {
@@ -203,19 +324,88 @@ func (b *BeaconState) HashTreeRoot(ctx context.Context) ([32]byte, error) {
 	return bytesutil.ToBytes32(b.merkleLayers[len(b.merkleLayers)-1][0]), nil
 }
 
-// FieldReferencesCount returns the reference count held by each field. This
-// also includes the field trie held by each field.
-func (b *BeaconState) FieldReferencesCount() map[string]uint64 {
-	refMap := make(map[string]uint64)
+// BalancesRoot returns the hash tree root of the validator balances. The root is
+// computed from the balances field trie, so only the branches containing balances
+// changed since the last computation are rehashed.
+func (b *BeaconState) BalancesRoot() ([32]byte, error) {
+	if !b.HasInnerState() {
+		return [32]byte{}, ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.rootSelector(Balances)
+}
+
+// ValidatorsRoot returns the hash tree root of the validator registry. The root is
+// computed from the validators field trie, which is keyed by validator index, so
+// only the validators changed since the last computation are rehashed.
+func (b *BeaconState) ValidatorsRoot() ([32]byte, error) {
+	if !b.HasInnerState() {
+		return [32]byte{}, ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.rootSelector(Validators)
+}
+
+// ValidatorInclusionProof returns the merkle proof of the validator at the given
+// index, from its leaf up to the root returned by ValidatorsRoot. The proof holds
+// the sibling node at each depth of the validators field trie, followed by the
+// length mix-in of the validator registry.
+func (b *BeaconState) ValidatorInclusionProof(idx uint64) ([][]byte, error) {
+	if !b.HasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if idx >= uint64(len(b.state.Validators)) {
+		return nil, errors.Errorf("index %d out of range", idx)
+	}
+	// Bring the field trie up to date with the validators before reading from it.
+	if _, err := b.rootSelector(Validators); err != nil {
+		return nil, err
+	}
+	return b.stateFieldLeaves[Validators].merkleProof(idx)
+}
+
+// RandaoMixesRoot returns the hash tree root of the randao mixes. The root is
+// computed from the randao mixes field trie, so a single changed mix only rehashes
+// its path to the root.
+func (b *BeaconState) RandaoMixesRoot() ([32]byte, error) {
+	if !b.HasInnerState() {
+		return [32]byte{}, ErrNilInnerState
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.rootSelector(RandaoMixes)
+}
+
+// FieldReferencesCount returns the reference count held by each shared field,
+// which is greater than one while the field is shared with copies of the state.
+func (b *BeaconState) FieldReferencesCount() map[FieldIndex]uint64 {
+	refMap := make(map[FieldIndex]uint64)
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 	for i, f := range b.sharedFieldReferences {
-		refMap[i.String()] = uint64(f.Refs())
+		refMap[i] = uint64(f.Refs())
 	}
+	return refMap
+}
+
+// FieldTrieReferencesCount returns the reference count held by the field trie of
+// each field, for the fields whose trie has been computed.
+func (b *BeaconState) FieldTrieReferencesCount() map[FieldIndex]uint64 {
+	refMap := make(map[FieldIndex]uint64)
+	b.lock.RLock()
+	defer b.lock.RUnlock()
 	for i, f := range b.stateFieldLeaves {
 		f.lock.RLock()
 		if len(f.fieldLayers) != 0 {
-			refMap[i.String()+"_trie"] = uint64(f.Refs())
+			refMap[i] = uint64(f.Refs())
 		}
 		f.lock.RUnlock()
 	}
@@ -253,22 +443,22 @@ func merkleize(leaves [][]byte) [][][]byte {
 	return layers
 }
 
-func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
+func (b *BeaconState) rootSelector(field FieldIndex) ([32]byte, error) {
 	hasher := hashutil.CustomSHA256Hasher()
 	switch field {
-	case genesisTime:
+	case GenesisTime:
 		return htrutils.Uint64Root(b.state.GenesisTime), nil
-	case genesisValidatorRoot:
+	case GenesisValidatorRoot:
 		return bytesutil.ToBytes32(b.state.GenesisValidatorsRoot), nil
-	case slot:
+	case Slot:
 		return htrutils.Uint64Root(b.state.Slot), nil
-	case eth1DepositIndex:
+	case Eth1DepositIndex:
 		return htrutils.Uint64Root(b.state.Eth1DepositIndex), nil
-	case fork:
+	case Fork:
 		return htrutils.ForkRoot(b.state.Fork)
-	case latestBlockHeader:
+	case LatestBlockHeader:
 		return stateutil.BlockHeaderRoot(b.state.LatestBlockHeader)
-	case blockRoots:
+	case BlockRoots:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.state.BlockRoots, params.BeaconConfig().SlotsPerHistoricalRoot)
 			if err != nil {
@@ -278,8 +468,8 @@ func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
 			delete(b.rebuildTrie, field)
 			return b.stateFieldLeaves[field].TrieRoot()
 		}
-		return b.recomputeFieldTrie(blockRoots, b.state.BlockRoots)
-	case stateRoots:
+		return b.recomputeFieldTrie(BlockRoots, b.state.BlockRoots)
+	case StateRoots:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.state.StateRoots, params.BeaconConfig().SlotsPerHistoricalRoot)
 			if err != nil {
@@ -289,12 +479,12 @@ func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
 			delete(b.rebuildTrie, field)
 			return b.stateFieldLeaves[field].TrieRoot()
 		}
-		return b.recomputeFieldTrie(stateRoots, b.state.StateRoots)
-	case historicalRoots:
+		return b.recomputeFieldTrie(StateRoots, b.state.StateRoots)
+	case HistoricalRoots:
 		return htrutils.HistoricalRootsRoot(b.state.HistoricalRoots)
-	case eth1Data:
+	case Eth1Data:
 		return stateutil.Eth1Root(hasher, b.state.Eth1Data)
-	case eth1DataVotes:
+	case Eth1DataVotes:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.state.Eth1DataVotes, params.BeaconConfig().EpochsPerEth1VotingPeriod*params.BeaconConfig().SlotsPerEpoch)
 			if err != nil {
@@ -305,20 +495,29 @@ func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
 			return b.stateFieldLeaves[field].TrieRoot()
 		}
 		return b.recomputeFieldTrie(field, b.state.Eth1DataVotes)
-	case validators:
+	case Validators:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.state.Validators, params.BeaconConfig().ValidatorRegistryLimit)
 			if err != nil {
 				return [32]byte{}, err
 			}
-			b.dirtyIndices[validators] = []uint64{}
-			delete(b.rebuildTrie, validators)
+			b.dirtyIndices[Validators] = []uint64{}
+			delete(b.rebuildTrie, Validators)
+			return b.stateFieldLeaves[field].TrieRoot()
+		}
+		return b.recomputeFieldTrie(Validators, b.state.Validators)
+	case Balances:
+		if b.rebuildTrie[field] {
+			err := b.resetFieldTrie(field, b.state.Balances, balancesChunkLimit())
+			if err != nil {
+				return [32]byte{}, err
+			}
+			b.dirtyIndices[field] = []uint64{}
+			delete(b.rebuildTrie, field)
 			return b.stateFieldLeaves[field].TrieRoot()
 		}
-		return b.recomputeFieldTrie(validators, b.state.Validators)
-	case balances:
-		return stateutil.ValidatorBalancesRoot(b.state.Balances)
-	case randaoMixes:
+		return b.recomputeFieldTrie(Balances, b.state.Balances)
+	case RandaoMixes:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.state.RandaoMixes, params.BeaconConfig().EpochsPerHistoricalVector)
 			if err != nil {
@@ -328,10 +527,10 @@ func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
 			delete(b.rebuildTrie, field)
 			return b.stateFieldLeaves[field].TrieRoot()
 		}
-		return b.recomputeFieldTrie(randaoMixes, b.state.RandaoMixes)
-	case slashings:
+		return b.recomputeFieldTrie(RandaoMixes, b.state.RandaoMixes)
+	case Slashings:
 		return htrutils.SlashingsRoot(b.state.Slashings)
-	case previousEpochAttestations:
+	case PreviousEpochAttestations:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.state.PreviousEpochAttestations, params.BeaconConfig().MaxAttestations*params.BeaconConfig().SlotsPerEpoch)
 			if err != nil {
@@ -342,7 +541,7 @@ func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
 			return b.stateFieldLeaves[field].TrieRoot()
 		}
 		return b.recomputeFieldTrie(field, b.state.PreviousEpochAttestations)
-	case currentEpochAttestations:
+	case CurrentEpochAttestations:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.state.CurrentEpochAttestations, params.BeaconConfig().MaxAttestations*params.BeaconConfig().SlotsPerEpoch)
 			if err != nil {
@@ -353,19 +552,19 @@ func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
 			return b.stateFieldLeaves[field].TrieRoot()
 		}
 		return b.recomputeFieldTrie(field, b.state.CurrentEpochAttestations)
-	case justificationBits:
+	case JustificationBits:
 		return bytesutil.ToBytes32(b.state.JustificationBits), nil
-	case previousJustifiedCheckpoint:
+	case PreviousJustifiedCheckpoint:
 		return htrutils.CheckpointRoot(hasher, b.state.PreviousJustifiedCheckpoint)
-	case currentJustifiedCheckpoint:
+	case CurrentJustifiedCheckpoint:
 		return htrutils.CheckpointRoot(hasher, b.state.CurrentJustifiedCheckpoint)
-	case finalizedCheckpoint:
+	case FinalizedCheckpoint:
 		return htrutils.CheckpointRoot(hasher, b.state.FinalizedCheckpoint)
 	}
 	return [32]byte{}, errors.New("invalid field index provided")
 }
 
-func (b *BeaconState) recomputeFieldTrie(index fieldIndex, elements interface{}) ([32]byte, error) {
+func (b *BeaconState) recomputeFieldTrie(index FieldIndex, elements interface{}) ([32]byte, error) {
 	fTrie := b.stateFieldLeaves[index]
 	if fTrie.Refs() > 1 {
 		fTrie.Lock()
@@ -389,7 +588,7 @@ func (b *BeaconState) recomputeFieldTrie(index fieldIndex, elements interface{})
 	return root, nil
 }
 
-func (b *BeaconState) resetFieldTrie(index fieldIndex, elements interface{}, length uint64) error {
+func (b *BeaconState) resetFieldTrie(index FieldIndex, elements interface{}, length uint64) error {
 	fTrie, err := NewFieldTrie(index, elements, length)
 	if err != nil {
 		return err
@@ -7,11 +7,14 @@ import (
 
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
 
 func TestInitializeFromProto(t *testing.T) {
@@ -98,6 +101,78 @@ func TestInitializeFromProtoUnsafe(t *testing.T) {
 	}
 }
 
+func TestInitializeFromProtoChecked(t *testing.T) {
+	testState, _ := testutil.DeterministicGenesisState(t, 64)
+
+	type test struct {
+		name  string
+		state *pbp2p.BeaconState
+		error string
+	}
+	initTests := []test{
+		{
+			name:  "nil state",
+			state: nil,
+			error: "received nil state",
+		},
+		{
+			name:  "empty state",
+			state: &pbp2p.BeaconState{},
+			error: "state is missing fork",
+		},
+		{
+			name: "missing eth1 data",
+			state: func() *pbp2p.BeaconState {
+				st := testState.CloneInnerState()
+				st.Eth1Data = nil
+				return st
+			}(),
+			error: "state is missing eth1 data",
+		},
+		{
+			name: "missing finalized checkpoint",
+			state: func() *pbp2p.BeaconState {
+				st := testState.CloneInnerState()
+				st.FinalizedCheckpoint = nil
+				return st
+			}(),
+			error: "state is missing finalized checkpoint",
+		},
+		{
+			name:  "full state",
+			state: testState.InnerStateUnsafe(),
+		},
+	}
+	for _, tt := range initTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := state.InitializeFromProtoChecked(tt.state)
+			if tt.error != "" {
+				assert.ErrorContains(t, tt.error, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBeaconState_CloneChecked(t *testing.T) {
+	testState, _ := testutil.DeterministicGenesisState(t, 64)
+	cloned, err := testState.CloneChecked()
+	require.NoError(t, err)
+	assert.DeepEqual(t, testState.CloneInnerState(), cloned)
+
+	partial := testState.CloneInnerState()
+	partial.Eth1Data = nil
+	st, err := state.InitializeFromProtoUnsafe(partial)
+	require.NoError(t, err)
+	_, err = st.CloneChecked()
+	assert.ErrorContains(t, "state is missing eth1 data", err)
+
+	var nilState *state.BeaconState
+	_, err = nilState.CloneChecked()
+	assert.ErrorContains(t, state.ErrNilInnerState.Error(), err)
+}
+
 func TestBeaconState_HashTreeRoot(t *testing.T) {
 	testState, _ := testutil.DeterministicGenesisState(t, 64)
 
@@ -240,6 +315,361 @@ func TestBeaconState_HashTreeRoot_FieldTrie(t *testing.T) {
 	}
 }
 
+func TestBeaconState_BalancesRoot(t *testing.T) {
+	testState, _ := testutil.DeterministicGenesisState(t, 64)
+
+	assertRoot := func() {
+		want, err := stateutil.ValidatorBalancesRoot(testState.Balances())
+		require.NoError(t, err)
+		root, err := testState.BalancesRoot()
+		require.NoError(t, err)
+		assert.Equal(t, want, root)
+	}
+	assertRoot()
+
+	require.NoError(t, testState.IncreaseBalance(5, 100))
+	require.NoError(t, testState.DecreaseBalance(63, 100))
+	assertRoot()
+
+	copied := testState.Copy()
+	require.NoError(t, testState.UpdateBalancesAtIndex(0, 1))
+	require.NoError(t, testState.AppendBalance(2))
+	assertRoot()
+
+	// The copy shares the trie and must not observe the changes made above.
+	want, err := stateutil.ValidatorBalancesRoot(copied.Balances())
+	require.NoError(t, err)
+	root, err := copied.BalancesRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+
+	require.NoError(t, testState.SetBalances([]uint64{1, 2, 3}))
+	assertRoot()
+
+	htr, err := testState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	genericHTR, err := testState.InnerStateUnsafe().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, genericHTR, htr)
+}
+
+func BenchmarkBalancesRoot_FieldTrie(b *testing.B) {
+	testState := balancesBenchmarkState(b, 300000)
+	_, err := testState.BalancesRoot()
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, testState.IncreaseBalance(uint64(i%300000), 1))
+		_, err := testState.BalancesRoot()
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkBalancesRoot_FullRecompute(b *testing.B) {
+	testState := balancesBenchmarkState(b, 300000)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, testState.IncreaseBalance(uint64(i%300000), 1))
+		_, err := stateutil.ValidatorBalancesRoot(testState.Balances())
+		require.NoError(b, err)
+	}
+}
+
+func balancesBenchmarkState(tb testing.TB, count uint64) *state.BeaconState {
+	bals := make([]uint64, count)
+	for i := range bals {
+		bals[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	testState, err := state.InitializeFromProto(&pbp2p.BeaconState{Balances: bals})
+	require.NoError(tb, err)
+	return testState
+}
+
+func TestBeaconState_Validate(t *testing.T) {
+	genesis, _ := testutil.DeterministicGenesisState(t, 16)
+	require.NoError(t, genesis.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(st *pbp2p.BeaconState)
+		error  string
+	}{
+		{
+			name:   "block roots",
+			modify: func(st *pbp2p.BeaconState) { st.BlockRoots = st.BlockRoots[1:] },
+			error:  "block roots length",
+		},
+		{
+			name:   "state roots",
+			modify: func(st *pbp2p.BeaconState) { st.StateRoots = append(st.StateRoots, make([]byte, 32)) },
+			error:  "state roots length",
+		},
+		{
+			name:   "randao mixes",
+			modify: func(st *pbp2p.BeaconState) { st.RandaoMixes = nil },
+			error:  "randao mixes length 0 does not match",
+		},
+		{
+			name:   "slashings",
+			modify: func(st *pbp2p.BeaconState) { st.Slashings = st.Slashings[:10] },
+			error:  "slashings length 10 does not match EPOCHS_PER_SLASHINGS_VECTOR",
+		},
+		{
+			name:   "balances",
+			modify: func(st *pbp2p.BeaconState) { st.Balances = append(st.Balances, 1) },
+			error:  "balances length 17 does not match validators length 16",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pbState := genesis.CloneInnerState()
+			tt.modify(pbState)
+			st, err := state.InitializeFromProtoUnsafe(pbState)
+			require.NoError(t, err)
+			assert.ErrorContains(t, tt.error, st.Validate())
+		})
+	}
+}
+
+func TestBeaconState_UnmarshalSSZ(t *testing.T) {
+	genesis, _ := testutil.DeterministicGenesisState(t, 64)
+	enc, err := genesis.InnerStateUnsafe().MarshalSSZ()
+	require.NoError(t, err)
+
+	// Decode over a state with a different registry, whose caches must not be reused.
+	testState, _ := testutil.DeterministicGenesisState(t, 16)
+	_, err = testState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, testState.UnmarshalSSZ(enc))
+
+	pubKey, err := genesis.PubkeyAtIndex(40)
+	require.NoError(t, err)
+	idx, ok := testState.ValidatorIndexByPubkey(pubKey)
+	require.Equal(t, true, ok)
+	assert.Equal(t, uint64(40), idx)
+	assert.Equal(t, 64, testState.NumValidators())
+
+	want, err := genesis.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	root, err := testState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+
+	// An invalid encoding leaves the state untouched.
+	assert.ErrorContains(t, "could not unmarshal beacon state", testState.UnmarshalSSZ([]byte{1, 2, 3}))
+	assert.Equal(t, 64, testState.NumValidators())
+}
+
+func TestBeaconState_ValidatorsRoot(t *testing.T) {
+	testState, _ := testutil.DeterministicGenesisState(t, 64)
+
+	assertRoot := func() {
+		want, err := stateutil.ValidatorRegistryRoot(testState.Validators())
+		require.NoError(t, err)
+		root, err := testState.ValidatorsRoot()
+		require.NoError(t, err)
+		assert.Equal(t, want, root)
+	}
+	assertRoot()
+
+	require.NoError(t, testState.SetValidatorSlashed(5))
+	require.NoError(t, testState.SetValidatorExitEpoch(63, 10))
+	assertRoot()
+
+	copied := testState.Copy()
+	val, err := testState.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	val.EffectiveBalance = 1
+	require.NoError(t, testState.UpdateValidatorAtIndex(0, val))
+	require.NoError(t, testState.AppendValidator(&eth.Validator{PublicKey: make([]byte, 48)}))
+	assertRoot()
+
+	// The copy shares the trie and must not observe the changes made above.
+	want, err := stateutil.ValidatorRegistryRoot(copied.Validators())
+	require.NoError(t, err)
+	root, err := copied.ValidatorsRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+
+	htr, err := testState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	genericHTR, err := testState.InnerStateUnsafe().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, genericHTR, htr)
+}
+
+func TestBeaconState_ValidatorInclusionProof(t *testing.T) {
+	testState, _ := testutil.DeterministicGenesisState(t, 64)
+
+	assertProof := func(idx uint64) {
+		proof, err := testState.ValidatorInclusionProof(idx)
+		require.NoError(t, err)
+		val, err := testState.ValidatorAtIndex(idx)
+		require.NoError(t, err)
+		node, err := stateutil.ValidatorRoot(hashutil.CustomSHA256Hasher(), val)
+		require.NoError(t, err)
+		// Walk up from the validator leaf, the last element being the length mix-in.
+		for i, sibling := range proof[:len(proof)-1] {
+			if (idx>>uint(i))%2 == 0 {
+				node = hashutil.Hash(append(node[:], sibling...))
+			} else {
+				node = hashutil.Hash(append(sibling, node[:]...))
+			}
+		}
+		node = hashutil.Hash(append(node[:], proof[len(proof)-1]...))
+		root, err := testState.ValidatorsRoot()
+		require.NoError(t, err)
+		assert.Equal(t, root, node)
+	}
+	for _, idx := range []uint64{0, 1, 31, 63} {
+		assertProof(idx)
+	}
+
+	// The proof reflects changes made to the validators since the last root.
+	require.NoError(t, testState.SetValidatorExitEpoch(1, 10))
+	require.NoError(t, testState.AppendValidator(&eth.Validator{PublicKey: make([]byte, 48)}))
+	for _, idx := range []uint64{0, 1, 64} {
+		assertProof(idx)
+	}
+
+	_, err := testState.ValidatorInclusionProof(65)
+	assert.ErrorContains(t, "index 65 out of range", err)
+}
+
+func BenchmarkValidatorsRoot_FieldTrie(b *testing.B) {
+	testState := validatorsBenchmarkState(b, 300000)
+	_, err := testState.ValidatorsRoot()
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, testState.SetValidatorExitEpoch(uint64(i%300000), uint64(i)))
+		_, err := testState.ValidatorsRoot()
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkValidatorsRoot_FullRecompute(b *testing.B) {
+	testState := validatorsBenchmarkState(b, 300000)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, testState.SetValidatorExitEpoch(uint64(i%300000), uint64(i)))
+		_, err := stateutil.ValidatorRegistryRoot(testState.Validators())
+		require.NoError(b, err)
+	}
+}
+
+func TestBeaconState_RandaoMixesRoot(t *testing.T) {
+	testState, _ := testutil.DeterministicGenesisState(t, 16)
+
+	assertRoot := func() {
+		want, err := stateutil.RootsArrayHashTreeRoot(testState.RandaoMixes(), params.BeaconConfig().EpochsPerHistoricalVector, "RandaoMixes")
+		require.NoError(t, err)
+		root, err := testState.RandaoMixesRoot()
+		require.NoError(t, err)
+		assert.Equal(t, want, root)
+	}
+	assertRoot()
+
+	require.NoError(t, testState.UpdateRandaoMixesAtIndex(1, bytesutil.ToBytes32([]byte("mix"))))
+	assertRoot()
+
+	copied := testState.Copy()
+	require.NoError(t, testState.UpdateRandaoMixesAtIndex(params.BeaconConfig().EpochsPerHistoricalVector-1, [32]byte{'a'}))
+	assertRoot()
+
+	// The copy shares the trie and must not observe the change made above.
+	want, err := stateutil.RootsArrayHashTreeRoot(copied.RandaoMixes(), params.BeaconConfig().EpochsPerHistoricalVector, "RandaoMixes")
+	require.NoError(t, err)
+	root, err := copied.RandaoMixesRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+}
+
+func BenchmarkRandaoMixesRoot_FieldTrie(b *testing.B) {
+	testState, _ := testutil.DeterministicGenesisState(b, 16)
+	_, err := testState.RandaoMixesRoot()
+	require.NoError(b, err)
+	length := params.BeaconConfig().EpochsPerHistoricalVector
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, testState.UpdateRandaoMixesAtIndex(uint64(i)%length, [32]byte{byte(i)}))
+		_, err := testState.RandaoMixesRoot()
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkRandaoMixesRoot_FullRecompute(b *testing.B) {
+	testState, _ := testutil.DeterministicGenesisState(b, 16)
+	length := params.BeaconConfig().EpochsPerHistoricalVector
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, testState.UpdateRandaoMixesAtIndex(uint64(i)%length, [32]byte{byte(i)}))
+		_, err := stateutil.RootsArrayHashTreeRoot(testState.RandaoMixes(), length, "RandaoMixes")
+		require.NoError(b, err)
+	}
+}
+
+func validatorsBenchmarkState(tb testing.TB, count uint64) *state.BeaconState {
+	vals := make([]*eth.Validator, count)
+	for i := range vals {
+		vals[i] = &eth.Validator{
+			PublicKey:             make([]byte, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	testState, err := state.InitializeFromProto(&pbp2p.BeaconState{Validators: vals})
+	require.NoError(tb, err)
+	return testState
+}
+
+func TestBeaconState_FieldReferencesCount(t *testing.T) {
+	st0 := testutil.NewBeaconState()
+	st1 := st0.Copy()
+	refs := st0.FieldReferencesCount()
+	assert.Equal(t, uint64(2), refs[state.Validators])
+	assert.Equal(t, uint64(2), refs[state.RandaoMixes])
+
+	require.NoError(t, st1.AppendValidator(&eth.Validator{}))
+	assert.Equal(t, uint64(1), st0.FieldReferencesCount()[state.Validators])
+	assert.Equal(t, uint64(1), st1.FieldReferencesCount()[state.Validators])
+	assert.Equal(t, uint64(2), st1.FieldReferencesCount()[state.RandaoMixes])
+	assert.Equal(t, "validators", state.Validators.String())
+}
+
+func TestBeaconState_EqualsIgnoringCache(t *testing.T) {
+	st0, _ := testutil.DeterministicGenesisState(t, 16)
+	st1 := st0.Copy()
+
+	// Only the first state has its merkle layers and field tries populated.
+	_, err := st0.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, true, st0.EqualsIgnoringCache(st1))
+	assert.Equal(t, true, st1.EqualsIgnoringCache(st0))
+
+	st2, err := state.InitializeFromProto(st0.CloneInnerState())
+	require.NoError(t, err)
+	assert.Equal(t, true, st0.EqualsIgnoringCache(st2))
+
+	require.NoError(t, st1.UpdateBalancesAtIndex(0, 1))
+	assert.Equal(t, false, st0.EqualsIgnoringCache(st1))
+	assert.Equal(t, false, st0.EqualsIgnoringCache(nil))
+}
+
 func TestBeaconState_AppendValidator_DoesntMutateCopy(t *testing.T) {
 	st0 := testutil.NewBeaconState()
 	st1 := st0.Copy()
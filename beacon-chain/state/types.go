@@ -1,6 +1,7 @@
 package state
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -11,63 +12,79 @@ import (
 )
 
 func init() {
-	fieldMap = make(map[fieldIndex]dataType, params.BeaconConfig().BeaconStateFieldCount)
+	fieldMap = make(map[FieldIndex]dataType, params.BeaconConfig().BeaconStateFieldCount)
 
 	// Initialize the fixed sized arrays.
-	fieldMap[blockRoots] = basicArray
-	fieldMap[stateRoots] = basicArray
-	fieldMap[randaoMixes] = basicArray
+	fieldMap[BlockRoots] = basicArray
+	fieldMap[StateRoots] = basicArray
+	fieldMap[RandaoMixes] = basicArray
 
 	// Initialize the composite arrays.
-	fieldMap[eth1DataVotes] = compositeArray
-	fieldMap[validators] = compositeArray
-	fieldMap[previousEpochAttestations] = compositeArray
-	fieldMap[currentEpochAttestations] = compositeArray
+	fieldMap[Eth1DataVotes] = compositeArray
+	fieldMap[Validators] = compositeArray
+	fieldMap[PreviousEpochAttestations] = compositeArray
+	fieldMap[CurrentEpochAttestations] = compositeArray
+
+	// Initialize the packed basic arrays.
+	fieldMap[Balances] = packedArray
 }
 
-type fieldIndex int
+// FieldIndex is the position of a field in the beacon state, following the
+// field order of the protobuf beacon state.
+type FieldIndex int
 
 // dataType signifies the data type of the field.
 type dataType int
 
 // Below we define a set of useful enum values for the field
-// indices of the beacon state. For example, genesisTime is the
+// indices of the beacon state. For example, GenesisTime is the
 // 0th field of the beacon state. This is helpful when we are
 // updating the Merkle branches up the trie representation
 // of the beacon state.
 const (
-	genesisTime fieldIndex = iota
-	genesisValidatorRoot
-	slot
-	fork
-	latestBlockHeader
-	blockRoots
-	stateRoots
-	historicalRoots
-	eth1Data
-	eth1DataVotes
-	eth1DepositIndex
-	validators
-	balances
-	randaoMixes
-	slashings
-	previousEpochAttestations
-	currentEpochAttestations
-	justificationBits
-	previousJustifiedCheckpoint
-	currentJustifiedCheckpoint
-	finalizedCheckpoint
+	GenesisTime FieldIndex = iota
+	GenesisValidatorRoot
+	Slot
+	Fork
+	LatestBlockHeader
+	BlockRoots
+	StateRoots
+	HistoricalRoots
+	Eth1Data
+	Eth1DataVotes
+	Eth1DepositIndex
+	Validators
+	Balances
+	RandaoMixes
+	Slashings
+	PreviousEpochAttestations
+	CurrentEpochAttestations
+	JustificationBits
+	PreviousJustifiedCheckpoint
+	CurrentJustifiedCheckpoint
+	FinalizedCheckpoint
 )
 
 // List of current data types the state supports.
 const (
 	basicArray dataType = iota
 	compositeArray
+	packedArray
 )
 
+// Phase0 is the version of the beacon state schema defined by the phase 0
+// specification. Forks which change the layout of the state add a version here.
+const Phase0 = 0
+
+// unsupportedFields keeps track, for each state version, of the fields which do
+// not exist in the schema of that version.
+var unsupportedFields = map[int]map[FieldIndex]bool{
+	Phase0: {},
+}
+
 // fieldMap keeps track of each field
 // to its corresponding data type.
-var fieldMap map[fieldIndex]dataType
+var fieldMap map[FieldIndex]dataType
 
 // Reference structs are shared across BeaconState copies to understand when the state must use
 // copy-on-write for shared fields or may modify a field in place when it holds the only reference
@@ -79,68 +96,110 @@ type reference struct {
 	lock sync.RWMutex
 }
 
+// ValidatorStatus describes the status of a validator at a given epoch, as
+// derived from its activation epoch, exit epoch and slashed fields.
+type ValidatorStatus int
+
+// List of validator statuses a validator can be filtered by.
+const (
+	// ValidatorPending is a validator which has not been activated yet.
+	ValidatorPending ValidatorStatus = iota
+	// ValidatorActive is a validator which is activated and has not exited yet.
+	ValidatorActive
+	// ValidatorExited is a validator whose exit epoch has been reached.
+	ValidatorExited
+	// ValidatorSlashed is a validator which has been slashed, regardless of its
+	// activation and exit epochs.
+	ValidatorSlashed
+)
+
 // ErrNilInnerState returns when the inner state is nil and no copy set or get
 // operations can be performed on state.
 var ErrNilInnerState = errors.New("nil inner state")
 
+// FieldNotSupportedError is returned when a field is accessed on a beacon state
+// whose version does not define it.
+type FieldNotSupportedError struct {
+	Field   FieldIndex
+	Version int
+}
+
+// Error returns the error message.
+func (e *FieldNotSupportedError) Error() string {
+	return fmt.Sprintf("field %s not supported at state version %d", e.Field, e.Version)
+}
+
 // BeaconState defines a struct containing utilities for the eth2 chain state, defining
 // getters and setters for its respective values and helpful functions such as HashTreeRoot().
 type BeaconState struct {
 	state                 *pbp2p.BeaconState
 	lock                  sync.RWMutex
-	dirtyFields           map[fieldIndex]interface{}
-	dirtyIndices          map[fieldIndex][]uint64
-	stateFieldLeaves      map[fieldIndex]*FieldTrie
-	rebuildTrie           map[fieldIndex]bool
+	dirtyFields           map[FieldIndex]interface{}
+	dirtyIndices          map[FieldIndex][]uint64
+	stateFieldLeaves      map[FieldIndex]*FieldTrie
+	rebuildTrie           map[FieldIndex]bool
 	valMapHandler         *validatorMapHandler
 	merkleLayers          [][][]byte
-	sharedFieldReferences map[fieldIndex]*reference
+	sharedFieldReferences map[FieldIndex]*reference
+	// activeIndices caches the active validator indices per epoch, and is
+	// cleared whenever the validators change.
+	activeIndices *activeIndicesCache
+	// proposerIndex caches the proposer index of the current slot, and is
+	// cleared whenever the validators or the randao mixes change.
+	proposerIndex *proposerIndexCache
+	// version is the schema version of the state, such as Phase0.
+	version int
+	// dirtyValidators tracks the validator indices changed since the last call
+	// to ClearDirtyValidators, so that only those need to be persisted.
+	dirtyValidators map[uint64]bool
+	// fieldObservers are notified whenever a setter marks a field as dirty.
+	fieldObservers []func(field FieldIndex)
 }
 
 // String returns the name of the field index.
-func (f fieldIndex) String() string {
+func (f FieldIndex) String() string {
 	switch f {
-	case genesisTime:
+	case GenesisTime:
 		return "genesisTime"
-	case genesisValidatorRoot:
+	case GenesisValidatorRoot:
 		return "genesisValidatorRoot"
-	case slot:
+	case Slot:
 		return "slot"
-	case fork:
+	case Fork:
 		return "fork"
-	case latestBlockHeader:
+	case LatestBlockHeader:
 		return "latestBlockHeader"
-	case blockRoots:
+	case BlockRoots:
 		return "blockRoots"
-	case stateRoots:
+	case StateRoots:
 		return "stateRoots"
-	case historicalRoots:
+	case HistoricalRoots:
 		return "historicalRoots"
-	case eth1Data:
+	case Eth1Data:
 		return "eth1Data"
-	case eth1DataVotes:
+	case Eth1DataVotes:
 		return "eth1DataVotes"
-	case eth1DepositIndex:
+	case Eth1DepositIndex:
 		return "eth1DepositIndex"
-	case validators:
+	case Validators:
 		return "validators"
-	case balances:
+	case Balances:
 		return "balances"
-	case randaoMixes:
+	case RandaoMixes:
 		return "randaoMixes"
-	case slashings:
+	case Slashings:
 		return "slashings"
-	case previousEpochAttestations:
+	case PreviousEpochAttestations:
 		return "previousEpochAttestations"
-	case currentEpochAttestations:
+	case CurrentEpochAttestations:
 		return "currentEpochAttestations"
-	case justificationBits:
+	case JustificationBits:
 		return "justificationBits"
-	case previousJustifiedCheckpoint:
+	case PreviousJustifiedCheckpoint:
 		return "previousJustifiedCheckpoint"
-	case currentJustifiedCheckpoint:
+	case CurrentJustifiedCheckpoint:
 		return "currentJustifiedCheckpoint"
-	case finalizedCheckpoint:
+	case FinalizedCheckpoint:
 		return "finalizedCheckpoint"
 	default:
 		return ""
@@ -54,6 +54,25 @@ func TestBeaconState_ProtoBeaconStateCompatibility(t *testing.T) {
 	assert.Equal(t, r1, r2, "Mismatched roots")
 }
 
+func TestBeaconState_CloneInnerState_EmptyAttestations(t *testing.T) {
+	params.UseMinimalConfig()
+	genesis := setupGenesisState(t, 64)
+	genesis.PreviousEpochAttestations = []*pb.PendingAttestation{}
+	genesis.CurrentEpochAttestations = []*pb.PendingAttestation{}
+	st, err := stateTrie.InitializeFromProto(genesis)
+	require.NoError(t, err)
+
+	cloned := st.CloneInnerState()
+	assert.Equal(t, true, cloned.PreviousEpochAttestations == nil)
+	assert.Equal(t, true, cloned.CurrentEpochAttestations == nil)
+	assert.Equal(t, true, proto.Equal(genesis, cloned), "Cloned state did not match")
+
+	require.NoError(t, st.AppendCurrentEpochAttestations(&pb.PendingAttestation{InclusionDelay: 1}))
+	cloned = st.CloneInnerState()
+	require.Equal(t, 1, len(cloned.CurrentEpochAttestations))
+	assert.Equal(t, uint64(1), cloned.CurrentEpochAttestations[0].InclusionDelay)
+}
+
 func setupGenesisState(tb testing.TB, count uint64) *pb.BeaconState {
 	genesisState, _, err := interop.GenerateGenesisState(0, count)
 	require.NoError(tb, err, "Could not generate genesis beacon state")
@@ -146,6 +165,70 @@ func BenchmarkStateClone_Manual(b *testing.B) {
 	}
 }
 
+func BenchmarkStateClone_EmptyAttestations(b *testing.B) {
+	b.StopTimer()
+	params.UseMinimalConfig()
+	genesis := setupGenesisState(b, 64)
+	// Both attestation lists are empty after an epoch transition.
+	genesis.PreviousEpochAttestations = []*pb.PendingAttestation{}
+	genesis.CurrentEpochAttestations = []*pb.PendingAttestation{}
+	st, err := stateTrie.InitializeFromProto(genesis)
+	require.NoError(b, err)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = st.CloneInnerState()
+	}
+}
+
+func BenchmarkStateCopy_LargeState(b *testing.B) {
+	b.StopTimer()
+	params.UseMinimalConfig()
+	genesis := setupGenesisState(b, 16384)
+	st, err := stateTrie.InitializeFromProto(genesis)
+	require.NoError(b, err)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = st.Copy()
+	}
+}
+
+func BenchmarkStateCloneInnerState_LargeState(b *testing.B) {
+	b.StopTimer()
+	params.UseMinimalConfig()
+	genesis := setupGenesisState(b, 16384)
+	st, err := stateTrie.InitializeFromProto(genesis)
+	require.NoError(b, err)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = st.CloneInnerState()
+	}
+}
+
+func BenchmarkStateCloneValidators_LargeState(b *testing.B) {
+	b.StopTimer()
+	params.UseMinimalConfig()
+	genesis := setupGenesisState(b, 16384)
+	st, err := stateTrie.InitializeFromProto(genesis)
+	require.NoError(b, err)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = st.CloneValidators()
+	}
+}
+
+func BenchmarkStateCloneInnerStateValidators_LargeState(b *testing.B) {
+	b.StopTimer()
+	params.UseMinimalConfig()
+	genesis := setupGenesisState(b, 16384)
+	st, err := stateTrie.InitializeFromProto(genesis)
+	require.NoError(b, err)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		// Clone the full state only to keep its validators.
+		_ = st.CloneInnerState().Validators
+	}
+}
+
 func cloneValidatorsWithProto(vals []*ethpb.Validator) []*ethpb.Validator {
 	var ok bool
 	res := make([]*ethpb.Validator, len(vals))
@@ -185,7 +268,7 @@ func TestBeaconState_ImmutabilityWithSharedResources(t *testing.T) {
 
 	// Randao mixes
 	require.DeepEqual(t, a.RandaoMixes(), b.RandaoMixes(), "Test precondition failed, fields are not equal")
-	require.NoError(t, a.UpdateRandaoMixesAtIndex(1, []byte("foo")))
+	require.NoError(t, a.UpdateRandaoMixesAtIndex(1, bytesutil.ToBytes32([]byte("foo"))))
 	if reflect.DeepEqual(a.RandaoMixes(), b.RandaoMixes()) {
 		t.Error("Expect a.RandaoMixes() to be different from b.RandaoMixes()")
 	}
@@ -61,6 +61,46 @@ func (SignResponse_Status) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_795e98bd0a473d79, []int{2, 0}
 }
 
+type ListSupportedSigningTypesResponse_SigningType int32
+
+const (
+	ListSupportedSigningTypesResponse_UNKNOWN                         ListSupportedSigningTypesResponse_SigningType = 0
+	ListSupportedSigningTypesResponse_BLOCK                           ListSupportedSigningTypesResponse_SigningType = 1
+	ListSupportedSigningTypesResponse_ATTESTATION_DATA                ListSupportedSigningTypesResponse_SigningType = 2
+	ListSupportedSigningTypesResponse_AGGREGATE_ATTESTATION_AND_PROOF ListSupportedSigningTypesResponse_SigningType = 3
+	ListSupportedSigningTypesResponse_EXIT                            ListSupportedSigningTypesResponse_SigningType = 4
+	ListSupportedSigningTypesResponse_SLOT                            ListSupportedSigningTypesResponse_SigningType = 5
+	ListSupportedSigningTypesResponse_EPOCH                           ListSupportedSigningTypesResponse_SigningType = 6
+)
+
+var ListSupportedSigningTypesResponse_SigningType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "BLOCK",
+	2: "ATTESTATION_DATA",
+	3: "AGGREGATE_ATTESTATION_AND_PROOF",
+	4: "EXIT",
+	5: "SLOT",
+	6: "EPOCH",
+}
+
+var ListSupportedSigningTypesResponse_SigningType_value = map[string]int32{
+	"UNKNOWN":                         0,
+	"BLOCK":                           1,
+	"ATTESTATION_DATA":                2,
+	"AGGREGATE_ATTESTATION_AND_PROOF": 3,
+	"EXIT":                            4,
+	"SLOT":                            5,
+	"EPOCH":                           6,
+}
+
+func (x ListSupportedSigningTypesResponse_SigningType) String() string {
+	return proto.EnumName(ListSupportedSigningTypesResponse_SigningType_name, int32(x))
+}
+
+func (ListSupportedSigningTypesResponse_SigningType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_795e98bd0a473d79, []int{7, 0}
+}
+
 type ListPublicKeysResponse struct {
 	ValidatingPublicKeys [][]byte `protobuf:"bytes,2,rep,name=validating_public_keys,json=validatingPublicKeys,proto3" json:"validating_public_keys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -109,9 +149,11 @@ func (m *ListPublicKeysResponse) GetValidatingPublicKeys() [][]byte {
 }
 
 type SignRequest struct {
-	PublicKey       []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
-	SigningRoot     []byte `protobuf:"bytes,2,opt,name=signing_root,json=signingRoot,proto3" json:"signing_root,omitempty"`
-	SignatureDomain []byte `protobuf:"bytes,3,opt,name=signature_domain,json=signatureDomain,proto3" json:"signature_domain,omitempty"`
+	PublicKey             []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	SigningRoot           []byte `protobuf:"bytes,2,opt,name=signing_root,json=signingRoot,proto3" json:"signing_root,omitempty"`
+	SignatureDomain       []byte `protobuf:"bytes,3,opt,name=signature_domain,json=signatureDomain,proto3" json:"signature_domain,omitempty"`
+	ForkVersion           []byte `protobuf:"bytes,4,opt,name=fork_version,json=forkVersion,proto3" json:"fork_version,omitempty"`
+	GenesisValidatorsRoot []byte `protobuf:"bytes,5,opt,name=genesis_validators_root,json=genesisValidatorsRoot,proto3" json:"genesis_validators_root,omitempty"`
 	// Types that are valid to be assigned to Object:
 	//	*SignRequest_Block
 	//	*SignRequest_AttestationData
@@ -218,6 +260,20 @@ func (m *SignRequest) GetSignatureDomain() []byte {
 	return nil
 }
 
+func (m *SignRequest) GetForkVersion() []byte {
+	if m != nil {
+		return m.ForkVersion
+	}
+	return nil
+}
+
+func (m *SignRequest) GetGenesisValidatorsRoot() []byte {
+	if m != nil {
+		return m.GenesisValidatorsRoot
+	}
+	return nil
+}
+
 func (m *SignRequest) GetBlock() *v1alpha1.BeaconBlock {
 	if x, ok := m.GetObject().(*SignRequest_Block); ok {
 		return x.Block
@@ -327,11 +383,268 @@ func (m *SignResponse) GetStatus() SignResponse_Status {
 	return SignResponse_UNKNOWN
 }
 
+type ListAccountsByStatusRequest struct {
+	Status               v1alpha1.ValidatorStatus `protobuf:"varint,1,opt,name=status,proto3,enum=ethereum.eth.v1alpha1.ValidatorStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ListAccountsByStatusRequest) Reset()         { *m = ListAccountsByStatusRequest{} }
+func (m *ListAccountsByStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccountsByStatusRequest) ProtoMessage()    {}
+func (*ListAccountsByStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_795e98bd0a473d79, []int{3}
+}
+func (m *ListAccountsByStatusRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAccountsByStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAccountsByStatusRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListAccountsByStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccountsByStatusRequest.Merge(m, src)
+}
+func (m *ListAccountsByStatusRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAccountsByStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccountsByStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccountsByStatusRequest proto.InternalMessageInfo
+
+func (m *ListAccountsByStatusRequest) GetStatus() v1alpha1.ValidatorStatus {
+	if m != nil {
+		return m.Status
+	}
+	return v1alpha1.ValidatorStatus_UNKNOWN_STATUS
+}
+
+type ListAccountsByStatusResponse struct {
+	Accounts             []*AccountsWithStatus `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListAccountsByStatusResponse) Reset()         { *m = ListAccountsByStatusResponse{} }
+func (m *ListAccountsByStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAccountsByStatusResponse) ProtoMessage()    {}
+func (*ListAccountsByStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_795e98bd0a473d79, []int{4}
+}
+func (m *ListAccountsByStatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAccountsByStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAccountsByStatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListAccountsByStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccountsByStatusResponse.Merge(m, src)
+}
+func (m *ListAccountsByStatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAccountsByStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccountsByStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccountsByStatusResponse proto.InternalMessageInfo
+
+func (m *ListAccountsByStatusResponse) GetAccounts() []*AccountsWithStatus {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type AccountsWithStatus struct {
+	Status               v1alpha1.ValidatorStatus `protobuf:"varint,1,opt,name=status,proto3,enum=ethereum.eth.v1alpha1.ValidatorStatus" json:"status,omitempty"`
+	ValidatingPublicKeys [][]byte                 `protobuf:"bytes,2,rep,name=validating_public_keys,json=validatingPublicKeys,proto3" json:"validating_public_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *AccountsWithStatus) Reset()         { *m = AccountsWithStatus{} }
+func (m *AccountsWithStatus) String() string { return proto.CompactTextString(m) }
+func (*AccountsWithStatus) ProtoMessage()    {}
+func (*AccountsWithStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_795e98bd0a473d79, []int{5}
+}
+func (m *AccountsWithStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccountsWithStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccountsWithStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AccountsWithStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsWithStatus.Merge(m, src)
+}
+func (m *AccountsWithStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccountsWithStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsWithStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsWithStatus proto.InternalMessageInfo
+
+func (m *AccountsWithStatus) GetStatus() v1alpha1.ValidatorStatus {
+	if m != nil {
+		return m.Status
+	}
+	return v1alpha1.ValidatorStatus_UNKNOWN_STATUS
+}
+
+func (m *AccountsWithStatus) GetValidatingPublicKeys() [][]byte {
+	if m != nil {
+		return m.ValidatingPublicKeys
+	}
+	return nil
+}
+
+type ReloadKeystoresResponse struct {
+	Added                uint64   `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed              uint64   `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadKeystoresResponse) Reset()         { *m = ReloadKeystoresResponse{} }
+func (m *ReloadKeystoresResponse) String() string { return proto.CompactTextString(m) }
+func (*ReloadKeystoresResponse) ProtoMessage()    {}
+func (*ReloadKeystoresResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_795e98bd0a473d79, []int{6}
+}
+func (m *ReloadKeystoresResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReloadKeystoresResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReloadKeystoresResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReloadKeystoresResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadKeystoresResponse.Merge(m, src)
+}
+func (m *ReloadKeystoresResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReloadKeystoresResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadKeystoresResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadKeystoresResponse proto.InternalMessageInfo
+
+func (m *ReloadKeystoresResponse) GetAdded() uint64 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *ReloadKeystoresResponse) GetRemoved() uint64 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+type ListSupportedSigningTypesResponse struct {
+	SigningTypes         []ListSupportedSigningTypesResponse_SigningType `protobuf:"varint,1,rep,packed,name=signing_types,json=signingTypes,proto3,enum=ethereum.validator.accounts.v2.ListSupportedSigningTypesResponse_SigningType" json:"signing_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
+	XXX_unrecognized     []byte                                          `json:"-"`
+	XXX_sizecache        int32                                           `json:"-"`
+}
+
+func (m *ListSupportedSigningTypesResponse) Reset()         { *m = ListSupportedSigningTypesResponse{} }
+func (m *ListSupportedSigningTypesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSupportedSigningTypesResponse) ProtoMessage()    {}
+func (*ListSupportedSigningTypesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_795e98bd0a473d79, []int{7}
+}
+func (m *ListSupportedSigningTypesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListSupportedSigningTypesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListSupportedSigningTypesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListSupportedSigningTypesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSupportedSigningTypesResponse.Merge(m, src)
+}
+func (m *ListSupportedSigningTypesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListSupportedSigningTypesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSupportedSigningTypesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSupportedSigningTypesResponse proto.InternalMessageInfo
+
+func (m *ListSupportedSigningTypesResponse) GetSigningTypes() []ListSupportedSigningTypesResponse_SigningType {
+	if m != nil {
+		return m.SigningTypes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("ethereum.validator.accounts.v2.SignResponse_Status", SignResponse_Status_name, SignResponse_Status_value)
+	proto.RegisterEnum("ethereum.validator.accounts.v2.ListSupportedSigningTypesResponse_SigningType", ListSupportedSigningTypesResponse_SigningType_name, ListSupportedSigningTypesResponse_SigningType_value)
 	proto.RegisterType((*ListPublicKeysResponse)(nil), "ethereum.validator.accounts.v2.ListPublicKeysResponse")
 	proto.RegisterType((*SignRequest)(nil), "ethereum.validator.accounts.v2.SignRequest")
 	proto.RegisterType((*SignResponse)(nil), "ethereum.validator.accounts.v2.SignResponse")
+	proto.RegisterType((*ListAccountsByStatusRequest)(nil), "ethereum.validator.accounts.v2.ListAccountsByStatusRequest")
+	proto.RegisterType((*ListAccountsByStatusResponse)(nil), "ethereum.validator.accounts.v2.ListAccountsByStatusResponse")
+	proto.RegisterType((*AccountsWithStatus)(nil), "ethereum.validator.accounts.v2.AccountsWithStatus")
+	proto.RegisterType((*ReloadKeystoresResponse)(nil), "ethereum.validator.accounts.v2.ReloadKeystoresResponse")
+	proto.RegisterType((*ListSupportedSigningTypesResponse)(nil), "ethereum.validator.accounts.v2.ListSupportedSigningTypesResponse")
 }
 
 func init() {
@@ -339,49 +652,73 @@ func init() {
 }
 
 var fileDescriptor_795e98bd0a473d79 = []byte{
-	// 671 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x6a, 0xdb, 0x4c,
-	0x10, 0xb5, 0x6c, 0xc7, 0xdf, 0x97, 0xb5, 0xdb, 0x98, 0x25, 0x18, 0xe1, 0xba, 0x8e, 0x2b, 0x42,
-	0x71, 0x69, 0x90, 0x88, 0x53, 0x7a, 0x11, 0x7a, 0x13, 0xc7, 0x2a, 0x0e, 0x09, 0x6e, 0x90, 0x49,
-	0x7a, 0x29, 0xd6, 0xf2, 0x44, 0x56, 0x22, 0xef, 0xaa, 0xd2, 0xca, 0xc4, 0xd0, 0xab, 0xf6, 0x05,
-	0x0a, 0x7d, 0x88, 0x3e, 0x42, 0x5f, 0xa1, 0x97, 0x85, 0xbe, 0x40, 0x09, 0x7d, 0x8d, 0x42, 0xd9,
-	0x95, 0xfc, 0x13, 0x70, 0xfa, 0x73, 0xe7, 0x39, 0x73, 0xce, 0xcc, 0xf8, 0xcc, 0x8e, 0xd0, 0x4e,
-	0x10, 0x32, 0xce, 0x8c, 0x09, 0xf1, 0xbd, 0x21, 0xe1, 0x2c, 0x34, 0x88, 0xe3, 0xb0, 0x98, 0xf2,
-	0xc8, 0x98, 0xb4, 0x8c, 0x2b, 0x98, 0x8e, 0x09, 0x25, 0x2e, 0x84, 0xba, 0xa4, 0xe1, 0x3a, 0xf0,
-	0x11, 0x84, 0x10, 0x8f, 0xf5, 0xb9, 0x40, 0x9f, 0x09, 0xf4, 0x49, 0xab, 0x2a, 0xf2, 0xc6, 0x64,
-	0x97, 0xf8, 0xc1, 0x88, 0xec, 0x1a, 0x84, 0x73, 0x88, 0x38, 0xe1, 0x1e, 0xa3, 0x89, 0xbe, 0xba,
-	0x75, 0x2b, 0x3f, 0x00, 0xe2, 0x30, 0x6a, 0x0f, 0x7c, 0xe6, 0x5c, 0xa5, 0x84, 0x9a, 0xcb, 0x98,
-	0xeb, 0x83, 0x41, 0x02, 0xcf, 0x20, 0x94, 0xb2, 0x44, 0x1d, 0xa5, 0xd9, 0x07, 0x69, 0x56, 0x46,
-	0x83, 0xf8, 0xc2, 0x80, 0x71, 0xc0, 0xa7, 0x49, 0x52, 0xeb, 0xa1, 0xca, 0x89, 0x17, 0xf1, 0xd3,
-	0x78, 0xe0, 0x7b, 0xce, 0x31, 0x4c, 0x23, 0x0b, 0xa2, 0x80, 0xd1, 0x08, 0xf0, 0x33, 0x54, 0x49,
-	0xc7, 0xf5, 0xa8, 0x6b, 0x07, 0x92, 0x60, 0x5f, 0xc1, 0x34, 0x52, 0xb3, 0x8d, 0x5c, 0xb3, 0x64,
-	0x6d, 0x2e, 0xb2, 0x0b, 0xb5, 0xf6, 0x33, 0x87, 0x8a, 0x7d, 0xcf, 0xa5, 0x16, 0xbc, 0x89, 0x21,
-	0xe2, 0xf8, 0x21, 0x42, 0x0b, 0xa9, 0xaa, 0x34, 0x94, 0x66, 0xc9, 0x5a, 0x0f, 0x66, 0x7c, 0xfc,
-	0x08, 0x95, 0x22, 0xcf, 0xa5, 0xa2, 0x43, 0xc8, 0x18, 0x57, 0xb3, 0x92, 0x50, 0x4c, 0x31, 0x8b,
-	0x31, 0x8e, 0x9f, 0xa0, 0xb2, 0x08, 0x09, 0x8f, 0x43, 0xb0, 0x87, 0x6c, 0x4c, 0x3c, 0xaa, 0xe6,
-	0x24, 0x6d, 0x63, 0x8e, 0x77, 0x24, 0x8c, 0xf7, 0xd1, 0x9a, 0xb4, 0x45, 0x85, 0x86, 0xd2, 0x2c,
-	0xb6, 0x34, 0x7d, 0x6e, 0x3c, 0xf0, 0x91, 0x3e, 0x73, 0x50, 0x6f, 0x4b, 0x07, 0xdb, 0x82, 0xd9,
-	0xcd, 0x58, 0x89, 0x04, 0xf7, 0x51, 0x79, 0xc9, 0x79, 0x7b, 0x48, 0x38, 0x51, 0x2f, 0x64, 0x99,
-	0xc7, 0x77, 0x94, 0x39, 0x58, 0xd0, 0x3b, 0x84, 0x93, 0x6e, 0xc6, 0xda, 0x20, 0xb7, 0x21, 0xfc,
-	0x16, 0x6d, 0x11, 0xd7, 0x0d, 0xc1, 0x25, 0x1c, 0xec, 0xe5, 0xf2, 0x84, 0x0e, 0xed, 0x20, 0x64,
-	0xec, 0x42, 0x75, 0x65, 0x8f, 0xbd, 0xbb, 0x7a, 0xcc, 0xd4, 0x4b, 0xcd, 0x0e, 0xe8, 0xf0, 0x54,
-	0x48, 0xbb, 0x19, 0xab, 0x46, 0x7e, 0x93, 0xc7, 0xfb, 0x28, 0x0f, 0xd7, 0x1e, 0x57, 0x47, 0xb2,
-	0xc5, 0xf6, 0x1d, 0x2d, 0xce, 0x99, 0x1f, 0x53, 0x4e, 0xc2, 0xa9, 0x79, 0xed, 0xf1, 0x6e, 0xc6,
-	0x92, 0x1a, 0xbc, 0x89, 0xf2, 0x91, 0xcf, 0xb8, 0xea, 0x35, 0x94, 0x66, 0x5e, 0xa0, 0x22, 0xc2,
-	0x15, 0xb4, 0x06, 0x01, 0x73, 0x46, 0xea, 0x65, 0x0a, 0x27, 0x61, 0xfb, 0x7f, 0x54, 0x60, 0x83,
-	0x4b, 0x70, 0xb8, 0xf6, 0x59, 0x41, 0xa5, 0x64, 0xff, 0xe9, 0x33, 0xaa, 0xa1, 0xf5, 0xf9, 0x9a,
-	0x66, 0xfb, 0x9f, 0x03, 0xf8, 0x18, 0x15, 0xc4, 0xd4, 0x71, 0x24, 0x37, 0x7f, 0x7f, 0xd9, 0x87,
-	0x95, 0xb7, 0xa2, 0x2f, 0xd7, 0xd6, 0xfb, 0x52, 0x6a, 0xa5, 0x25, 0xb4, 0x17, 0xa8, 0x90, 0x20,
-	0xb8, 0x88, 0xfe, 0x3b, 0xeb, 0x1d, 0xf7, 0x5e, 0xbd, 0xee, 0x95, 0x33, 0xf8, 0x1e, 0x5a, 0xef,
-	0x9f, 0x1d, 0x1e, 0x9a, 0x66, 0xc7, 0xec, 0x94, 0x15, 0x8c, 0x50, 0xa1, 0x63, 0xf6, 0x8e, 0xcc,
-	0x4e, 0x39, 0x2b, 0x7e, 0xbf, 0x3c, 0x38, 0x3a, 0x31, 0x3b, 0xe5, 0x5c, 0xeb, 0x53, 0x16, 0x95,
-	0x2c, 0x18, 0x33, 0x0e, 0xa2, 0x07, 0x84, 0xf8, 0x83, 0x82, 0x54, 0x71, 0x1b, 0xe7, 0x2b, 0xde,
-	0x39, 0xae, 0xe8, 0xc9, 0x55, 0xe9, 0xb3, 0xab, 0xd2, 0x4d, 0x71, 0x55, 0xd5, 0xe7, 0x7f, 0xfa,
-	0x03, 0xab, 0xaf, 0x4d, 0xdb, 0x7e, 0xf7, 0xed, 0xc7, 0xc7, 0x6c, 0x1d, 0xd7, 0x6e, 0x7d, 0x4a,
-	0x42, 0x39, 0xcf, 0x1c, 0xc2, 0xef, 0x15, 0x94, 0x17, 0xd3, 0xe1, 0xa7, 0x7f, 0xe7, 0x93, 0xbc,
-	0xc1, 0xea, 0xce, 0xbf, 0x98, 0xaa, 0x35, 0xe4, 0x24, 0x55, 0x4d, 0x5d, 0x35, 0x89, 0xd8, 0x5c,
-	0xbb, 0xf4, 0xe5, 0xa6, 0xae, 0x7c, 0xbd, 0xa9, 0x2b, 0xdf, 0x6f, 0xea, 0xca, 0xa0, 0x20, 0x1d,
-	0xd8, 0xfb, 0x15, 0x00, 0x00, 0xff, 0xff, 0x8e, 0x70, 0x82, 0xe4, 0x14, 0x05, 0x00, 0x00,
+	// 1043 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb5, 0x55, 0x5d, 0x6f, 0xdb, 0x54,
+	0x18, 0x9e, 0xf3, 0xb5, 0xf6, 0x6d, 0xb6, 0x46, 0x47, 0xa5, 0x33, 0x59, 0xd6, 0x76, 0x66, 0xa0,
+	0x21, 0x26, 0x87, 0x65, 0x68, 0x48, 0x30, 0x21, 0x39, 0x8d, 0xd7, 0x45, 0x2d, 0x49, 0xe5, 0x64,
+	0x1d, 0x37, 0xc8, 0x3a, 0x49, 0x4e, 0x1d, 0xaf, 0x89, 0x8f, 0xb1, 0x9d, 0x88, 0x48, 0x48, 0x48,
+	0x20, 0x71, 0xc1, 0x15, 0x12, 0x37, 0x48, 0x88, 0xdf, 0xc1, 0x5f, 0xe0, 0x12, 0x89, 0x1b, 0x24,
+	0x6e, 0x10, 0xe2, 0x87, 0x70, 0xce, 0xf1, 0x47, 0x92, 0x2e, 0xa6, 0x65, 0x13, 0x17, 0x96, 0x7c,
+	0xde, 0x8f, 0xe7, 0x7d, 0xce, 0xfb, 0x75, 0xe0, 0x9e, 0xeb, 0xd1, 0x80, 0x56, 0xa7, 0x78, 0x64,
+	0x0f, 0x70, 0x40, 0xbd, 0x2a, 0xee, 0xf7, 0xe9, 0xc4, 0x09, 0xfc, 0xea, 0xb4, 0x56, 0x3d, 0x23,
+	0xb3, 0x31, 0x76, 0xb0, 0x45, 0x3c, 0x55, 0x98, 0xa1, 0x1d, 0x12, 0x0c, 0x89, 0x47, 0x26, 0x63,
+	0x35, 0x71, 0x50, 0x63, 0x07, 0x75, 0x5a, 0x2b, 0x73, 0x7d, 0x75, 0x7a, 0x1f, 0x8f, 0xdc, 0x21,
+	0xbe, 0x5f, 0xc5, 0x41, 0x40, 0xfc, 0x00, 0x07, 0x36, 0x75, 0x42, 0xff, 0xf2, 0xee, 0x92, 0xbe,
+	0x47, 0x70, 0x9f, 0x3a, 0x66, 0x6f, 0x44, 0xfb, 0x67, 0x91, 0x41, 0x65, 0xc9, 0x60, 0x1e, 0x24,
+	0xd2, 0x5a, 0x94, 0x5a, 0x23, 0x52, 0xc5, 0xae, 0x5d, 0xc5, 0x8e, 0x43, 0x43, 0x6c, 0x3f, 0xd2,
+	0xde, 0x8c, 0xb4, 0xe2, 0xd4, 0x9b, 0x9c, 0x56, 0xc9, 0xd8, 0x0d, 0x66, 0xa1, 0x52, 0x69, 0xc1,
+	0xf6, 0x91, 0xed, 0x07, 0xc7, 0x93, 0xde, 0xc8, 0xee, 0x1f, 0x92, 0x99, 0x6f, 0x10, 0xdf, 0x65,
+	0xbe, 0x04, 0xbd, 0x07, 0xdb, 0x51, 0x1c, 0xdb, 0xb1, 0x4c, 0x57, 0x18, 0x98, 0xec, 0xe6, 0xbe,
+	0x9c, 0xd9, 0xcb, 0xde, 0x2d, 0x1a, 0x5b, 0x73, 0xed, 0xdc, 0x5b, 0xf9, 0x3d, 0x07, 0x1b, 0x1d,
+	0xdb, 0x72, 0x0c, 0xf2, 0xd9, 0x84, 0x5d, 0x12, 0xdd, 0x02, 0x98, 0xbb, 0xca, 0xd2, 0x9e, 0xc4,
+	0x3c, 0xd7, 0xdd, 0xd8, 0x1e, 0xdd, 0x86, 0xa2, 0xcf, 0xac, 0x79, 0x04, 0x8f, 0xd2, 0x80, 0x41,
+	0x73, 0x83, 0x8d, 0x48, 0x66, 0x30, 0x11, 0x7a, 0x1b, 0x4a, 0xfc, 0x88, 0x83, 0x89, 0x47, 0xcc,
+	0x01, 0x1d, 0x63, 0xdb, 0x91, 0xb3, 0xc2, 0x6c, 0x33, 0x91, 0x37, 0x84, 0x98, 0xa3, 0x9d, 0x52,
+	0xef, 0xcc, 0x9c, 0x12, 0xcf, 0x67, 0x09, 0x90, 0x73, 0x21, 0x1a, 0x97, 0x9d, 0x84, 0x22, 0xf4,
+	0x10, 0x6e, 0x58, 0xc4, 0x21, 0xbe, 0xed, 0x9b, 0x49, 0x16, 0xfd, 0x30, 0x76, 0x5e, 0x58, 0xbf,
+	0x16, 0xa9, 0x4f, 0x12, 0xad, 0x60, 0xf1, 0x01, 0xe4, 0x45, 0x3d, 0x64, 0xc2, 0xac, 0x36, 0x6a,
+	0x8a, 0x9a, 0x54, 0x9c, 0xfd, 0xa8, 0x71, 0x65, 0xd4, 0xba, 0x28, 0x5d, 0x9d, 0x5b, 0x3e, 0xb9,
+	0x62, 0x84, 0x2e, 0xa8, 0x03, 0xa5, 0x85, 0x92, 0x9b, 0x0c, 0x15, 0xcb, 0xa7, 0x02, 0xe6, 0xad,
+	0x14, 0x18, 0x6d, 0x6e, 0xde, 0x60, 0xd6, 0x0c, 0x6a, 0x13, 0x2f, 0x8b, 0xd0, 0x17, 0xb0, 0x8b,
+	0x2d, 0xcb, 0x23, 0x16, 0x0e, 0x88, 0xb9, 0x08, 0x8f, 0x9d, 0x81, 0xc9, 0x6a, 0x4b, 0x4f, 0x65,
+	0x4b, 0xc4, 0x78, 0x90, 0x16, 0x23, 0xf6, 0x5e, 0x08, 0xa6, 0x39, 0x83, 0x63, 0xee, 0xca, 0x02,
+	0x56, 0xf0, 0xbf, 0xe8, 0x59, 0x3a, 0x72, 0xe4, 0x73, 0x3b, 0x90, 0x87, 0x22, 0xc4, 0x9d, 0x94,
+	0x10, 0x27, 0x74, 0xc4, 0x26, 0x00, 0x7b, 0x33, 0x9d, 0xd9, 0x32, 0x4c, 0xe1, 0x83, 0xb6, 0x20,
+	0xe7, 0x8f, 0x58, 0xbe, 0x6d, 0xe6, 0x9b, 0xe3, 0x52, 0x7e, 0x42, 0xdb, 0x90, 0x27, 0x2e, 0xed,
+	0x0f, 0xe5, 0xe7, 0x91, 0x38, 0x3c, 0xd6, 0xd7, 0xa0, 0x40, 0x7b, 0xcf, 0x49, 0x3f, 0x50, 0x7e,
+	0x96, 0xa0, 0x18, 0xb6, 0x56, 0xd4, 0xa1, 0x15, 0x58, 0x4f, 0x3a, 0x20, 0x6e, 0xad, 0x44, 0x80,
+	0x0e, 0xa1, 0xc0, 0x59, 0x4f, 0x7c, 0xd1, 0x54, 0xd7, 0x17, 0xf3, 0xb0, 0x72, 0x48, 0xd5, 0x45,
+	0x6c, 0xb5, 0x23, 0x5c, 0x8d, 0x08, 0x42, 0x79, 0x04, 0x85, 0x50, 0x82, 0x36, 0xe0, 0xea, 0xd3,
+	0xd6, 0x61, 0xab, 0xfd, 0xac, 0x55, 0xba, 0x82, 0xae, 0xc1, 0x7a, 0xe7, 0xe9, 0xfe, 0xbe, 0xae,
+	0x37, 0xf4, 0x46, 0x49, 0x42, 0x00, 0x85, 0x86, 0xde, 0x6a, 0xb2, 0xff, 0x0c, 0xff, 0x7f, 0xac,
+	0x35, 0x8f, 0xd8, 0x7f, 0x56, 0xf9, 0x14, 0x6e, 0xf2, 0x21, 0xd3, 0xa2, 0x60, 0xf5, 0x59, 0x84,
+	0x1e, 0xcd, 0xc8, 0x47, 0x09, 0x53, 0x49, 0x30, 0x4d, 0xeb, 0x8a, 0xa4, 0x25, 0xcf, 0x91, 0x73,
+	0xa0, 0xb2, 0x1a, 0x3e, 0xca, 0x53, 0x0b, 0xd6, 0xe2, 0x7b, 0xb2, 0x08, 0x59, 0x56, 0xb0, 0xda,
+	0x45, 0xb9, 0x88, 0xb1, 0x9e, 0xd9, 0xc1, 0x30, 0x42, 0x4b, 0x30, 0x94, 0x6f, 0x25, 0x40, 0x2f,
+	0x1a, 0xbc, 0xea, 0x35, 0x5e, 0x72, 0xe1, 0x34, 0xe1, 0x86, 0x41, 0x46, 0x14, 0x0f, 0xf8, 0x89,
+	0x81, 0x92, 0xf9, 0xbd, 0xb7, 0x20, 0x8f, 0x07, 0x03, 0x32, 0x10, 0x7c, 0x72, 0x46, 0x78, 0x40,
+	0x32, 0x5c, 0xf5, 0xc8, 0x98, 0x4e, 0x99, 0x3c, 0x23, 0xe4, 0xf1, 0x51, 0xf9, 0x21, 0x03, 0xb7,
+	0x79, 0x22, 0x3b, 0x13, 0xd7, 0xa5, 0x5e, 0x40, 0x06, 0x9d, 0x70, 0x0d, 0x75, 0x67, 0xee, 0x02,
+	0xaa, 0x07, 0xd7, 0xe2, 0x95, 0x15, 0x70, 0x85, 0x48, 0xe9, 0xf5, 0xda, 0xc7, 0x17, 0xa5, 0xf4,
+	0x42, 0x64, 0x75, 0x41, 0x68, 0xc4, 0x6b, 0x51, 0x58, 0x28, 0x5f, 0x86, 0x4b, 0x35, 0x3a, 0x2f,
+	0xf7, 0xe0, 0x3a, 0xe4, 0xeb, 0x47, 0xed, 0xfd, 0x43, 0xd6, 0x7f, 0x5b, 0x50, 0xd2, 0xba, 0x5d,
+	0xbd, 0xd3, 0xd5, 0xba, 0xcd, 0x76, 0xcb, 0x6c, 0x68, 0x5d, 0x8d, 0x75, 0xe2, 0x1b, 0xb0, 0xab,
+	0x1d, 0x1c, 0x18, 0xfa, 0x81, 0xd6, 0xd5, 0xcd, 0x45, 0xbd, 0xd6, 0x6a, 0x98, 0xc7, 0x46, 0xbb,
+	0xfd, 0xb8, 0x94, 0x45, 0x6b, 0x90, 0xd3, 0x3f, 0x69, 0x76, 0x4b, 0x39, 0xfe, 0xd7, 0x39, 0x6a,
+	0x77, 0x4b, 0x79, 0x8e, 0xac, 0x1f, 0xb7, 0xf7, 0x9f, 0x94, 0x0a, 0xb5, 0x3f, 0x0a, 0x50, 0x34,
+	0x58, 0x9a, 0x02, 0xc2, 0x79, 0x10, 0x0f, 0x7d, 0x27, 0x81, 0xcc, 0x6f, 0x74, 0xb2, 0xa2, 0x26,
+	0x68, 0x5b, 0x0d, 0x9f, 0x1c, 0x35, 0x7e, 0x72, 0x54, 0x9d, 0x3f, 0x39, 0xe5, 0x87, 0x97, 0xc9,
+	0xd1, 0x8b, 0x4f, 0x91, 0x72, 0xe7, 0xab, 0xdf, 0xfe, 0xfe, 0x3e, 0xb3, 0x83, 0x2a, 0x4b, 0xaf,
+	0xb0, 0x27, 0xf8, 0x24, 0x22, 0xf4, 0xb5, 0xc4, 0x98, 0x33, 0x76, 0xe8, 0x9d, 0xcb, 0x4d, 0xba,
+	0x18, 0xbe, 0xf2, 0xbd, 0xff, 0xb2, 0x16, 0x94, 0x3d, 0xc1, 0xa4, 0xac, 0xc8, 0xab, 0x98, 0xf0,
+	0x92, 0xa1, 0x33, 0x00, 0xee, 0xd1, 0x09, 0x3c, 0x82, 0xc7, 0xff, 0x23, 0x95, 0xbb, 0xd2, 0xbb,
+	0x12, 0xfa, 0x51, 0x82, 0x9d, 0xe5, 0x2a, 0x9c, 0x5f, 0x02, 0xe8, 0xc3, 0xcb, 0xe4, 0x3c, 0x65,
+	0x33, 0x95, 0x1f, 0xbd, 0x9c, 0x73, 0x34, 0x29, 0xdf, 0x48, 0xb0, 0x79, 0x6e, 0x36, 0x53, 0x5b,
+	0xe3, 0xfd, 0x8b, 0x22, 0xa5, 0x0c, 0xb9, 0xa2, 0x88, 0x8a, 0x54, 0x94, 0xf2, 0xaa, 0x8a, 0x78,
+	0xc2, 0x09, 0xfd, 0x24, 0xc1, 0xeb, 0xa9, 0xe3, 0x97, 0x4a, 0x49, 0x7b, 0xe5, 0x89, 0x56, 0xde,
+	0x14, 0xe4, 0x76, 0xd1, 0xad, 0xb4, 0x76, 0x11, 0x1b, 0xa4, 0x5e, 0xfc, 0xe5, 0xaf, 0x1d, 0xe9,
+	0x57, 0xf6, 0xfd, 0xc9, 0xbe, 0x5e, 0x41, 0xf0, 0x78, 0xf0, 0x0f, 0x6d, 0x9f, 0x8f, 0x72, 0x83,
+	0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -398,6 +735,10 @@ const _ = grpc.SupportPackageIsVersion4
 type RemoteSignerClient interface {
 	ListValidatingPublicKeys(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ListPublicKeysResponse, error)
 	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	SignStream(ctx context.Context, opts ...grpc.CallOption) (RemoteSigner_SignStreamClient, error)
+	ListValidatingAccountsByStatus(ctx context.Context, in *ListAccountsByStatusRequest, opts ...grpc.CallOption) (*ListAccountsByStatusResponse, error)
+	ReloadKeystores(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ReloadKeystoresResponse, error)
+	ListSupportedSigningTypes(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ListSupportedSigningTypesResponse, error)
 }
 
 type remoteSignerClient struct {
@@ -426,10 +767,72 @@ func (c *remoteSignerClient) Sign(ctx context.Context, in *SignRequest, opts ...
 	return out, nil
 }
 
+func (c *remoteSignerClient) SignStream(ctx context.Context, opts ...grpc.CallOption) (RemoteSigner_SignStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RemoteSigner_serviceDesc.Streams[0], "/ethereum.validator.accounts.v2.RemoteSigner/SignStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteSignerSignStreamClient{stream}
+	return x, nil
+}
+
+type RemoteSigner_SignStreamClient interface {
+	Send(*SignRequest) error
+	Recv() (*SignResponse, error)
+	grpc.ClientStream
+}
+
+type remoteSignerSignStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteSignerSignStreamClient) Send(m *SignRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *remoteSignerSignStreamClient) Recv() (*SignResponse, error) {
+	m := new(SignResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteSignerClient) ListValidatingAccountsByStatus(ctx context.Context, in *ListAccountsByStatusRequest, opts ...grpc.CallOption) (*ListAccountsByStatusResponse, error) {
+	out := new(ListAccountsByStatusResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.validator.accounts.v2.RemoteSigner/ListValidatingAccountsByStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ReloadKeystores(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ReloadKeystoresResponse, error) {
+	out := new(ReloadKeystoresResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.validator.accounts.v2.RemoteSigner/ReloadKeystores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ListSupportedSigningTypes(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ListSupportedSigningTypesResponse, error) {
+	out := new(ListSupportedSigningTypesResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.validator.accounts.v2.RemoteSigner/ListSupportedSigningTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RemoteSignerServer is the server API for RemoteSigner service.
 type RemoteSignerServer interface {
 	ListValidatingPublicKeys(context.Context, *types.Empty) (*ListPublicKeysResponse, error)
 	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	SignStream(RemoteSigner_SignStreamServer) error
+	ListValidatingAccountsByStatus(context.Context, *ListAccountsByStatusRequest) (*ListAccountsByStatusResponse, error)
+	ReloadKeystores(context.Context, *types.Empty) (*ReloadKeystoresResponse, error)
+	ListSupportedSigningTypes(context.Context, *types.Empty) (*ListSupportedSigningTypesResponse, error)
 }
 
 // UnimplementedRemoteSignerServer can be embedded to have forward compatible implementations.
@@ -442,6 +845,18 @@ func (*UnimplementedRemoteSignerServer) ListValidatingPublicKeys(ctx context.Con
 func (*UnimplementedRemoteSignerServer) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
 }
+func (*UnimplementedRemoteSignerServer) SignStream(srv RemoteSigner_SignStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SignStream not implemented")
+}
+func (*UnimplementedRemoteSignerServer) ListValidatingAccountsByStatus(ctx context.Context, req *ListAccountsByStatusRequest) (*ListAccountsByStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListValidatingAccountsByStatus not implemented")
+}
+func (*UnimplementedRemoteSignerServer) ReloadKeystores(ctx context.Context, req *types.Empty) (*ReloadKeystoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadKeystores not implemented")
+}
+func (*UnimplementedRemoteSignerServer) ListSupportedSigningTypes(ctx context.Context, req *types.Empty) (*ListSupportedSigningTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSupportedSigningTypes not implemented")
+}
 
 func RegisterRemoteSignerServer(s *grpc.Server, srv RemoteSignerServer) {
 	s.RegisterService(&_RemoteSigner_serviceDesc, srv)
@@ -483,71 +898,170 @@ func _RemoteSigner_Sign_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
-var _RemoteSigner_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "ethereum.validator.accounts.v2.RemoteSigner",
-	HandlerType: (*RemoteSignerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListValidatingPublicKeys",
-			Handler:    _RemoteSigner_ListValidatingPublicKeys_Handler,
-		},
-		{
-			MethodName: "Sign",
-			Handler:    _RemoteSigner_Sign_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/validator/accounts/v2/keymanager.proto",
+func _RemoteSigner_SignStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RemoteSignerServer).SignStream(&remoteSignerSignStreamServer{stream})
 }
 
-func (m *ListPublicKeysResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type RemoteSigner_SignStreamServer interface {
+	Send(*SignResponse) error
+	Recv() (*SignRequest, error)
+	grpc.ServerStream
 }
 
-func (m *ListPublicKeysResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type remoteSignerSignStreamServer struct {
+	grpc.ServerStream
 }
 
-func (m *ListPublicKeysResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ValidatingPublicKeys) > 0 {
-		for iNdEx := len(m.ValidatingPublicKeys) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.ValidatingPublicKeys[iNdEx])
-			copy(dAtA[i:], m.ValidatingPublicKeys[iNdEx])
-			i = encodeVarintKeymanager(dAtA, i, uint64(len(m.ValidatingPublicKeys[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	return len(dAtA) - i, nil
+func (x *remoteSignerSignStreamServer) Send(m *SignResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *SignRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *remoteSignerSignStreamServer) Recv() (*SignRequest, error) {
+	m := new(SignRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *SignRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
+func _RemoteSigner_ListValidatingAccountsByStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsByStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ListValidatingAccountsByStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.validator.accounts.v2.RemoteSigner/ListValidatingAccountsByStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ListValidatingAccountsByStatus(ctx, req.(*ListAccountsByStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_ReloadKeystores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ReloadKeystores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.validator.accounts.v2.RemoteSigner/ReloadKeystores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ReloadKeystores(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_ListSupportedSigningTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ListSupportedSigningTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.validator.accounts.v2.RemoteSigner/ListSupportedSigningTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ListSupportedSigningTypes(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RemoteSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ethereum.validator.accounts.v2.RemoteSigner",
+	HandlerType: (*RemoteSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListValidatingPublicKeys",
+			Handler:    _RemoteSigner_ListValidatingPublicKeys_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _RemoteSigner_Sign_Handler,
+		},
+		{
+			MethodName: "ListValidatingAccountsByStatus",
+			Handler:    _RemoteSigner_ListValidatingAccountsByStatus_Handler,
+		},
+		{
+			MethodName: "ReloadKeystores",
+			Handler:    _RemoteSigner_ReloadKeystores_Handler,
+		},
+		{
+			MethodName: "ListSupportedSigningTypes",
+			Handler:    _RemoteSigner_ListSupportedSigningTypes_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SignStream",
+			Handler:       _RemoteSigner_SignStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/validator/accounts/v2/keymanager.proto",
+}
+
+func (m *ListPublicKeysResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListPublicKeysResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListPublicKeysResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ValidatingPublicKeys) > 0 {
+		for iNdEx := len(m.ValidatingPublicKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ValidatingPublicKeys[iNdEx])
+			copy(dAtA[i:], m.ValidatingPublicKeys[iNdEx])
+			i = encodeVarintKeymanager(dAtA, i, uint64(len(m.ValidatingPublicKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
@@ -569,6 +1083,20 @@ func (m *SignRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			}
 		}
 	}
+	if len(m.GenesisValidatorsRoot) > 0 {
+		i -= len(m.GenesisValidatorsRoot)
+		copy(dAtA[i:], m.GenesisValidatorsRoot)
+		i = encodeVarintKeymanager(dAtA, i, uint64(len(m.GenesisValidatorsRoot)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ForkVersion) > 0 {
+		i -= len(m.ForkVersion)
+		copy(dAtA[i:], m.ForkVersion)
+		i = encodeVarintKeymanager(dAtA, i, uint64(len(m.ForkVersion)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.SignatureDomain) > 0 {
 		i -= len(m.SignatureDomain)
 		copy(dAtA[i:], m.SignatureDomain)
@@ -752,89 +1280,293 @@ func (m *SignResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintKeymanager(dAtA []byte, offset int, v uint64) int {
-	offset -= sovKeymanager(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ListAccountsByStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ListPublicKeysResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *ListAccountsByStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAccountsByStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.ValidatingPublicKeys) > 0 {
-		for _, b := range m.ValidatingPublicKeys {
-			l = len(b)
-			n += 1 + l + sovKeymanager(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.Status != 0 {
+		i = encodeVarintKeymanager(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *SignRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListAccountsByStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAccountsByStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAccountsByStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.PublicKey)
-	if l > 0 {
-		n += 1 + l + sovKeymanager(uint64(l))
-	}
-	l = len(m.SigningRoot)
-	if l > 0 {
-		n += 1 + l + sovKeymanager(uint64(l))
-	}
-	l = len(m.SignatureDomain)
-	if l > 0 {
-		n += 1 + l + sovKeymanager(uint64(l))
-	}
-	if m.Object != nil {
-		n += m.Object.Size()
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Accounts) > 0 {
+		for iNdEx := len(m.Accounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Accounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintKeymanager(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *SignRequest_Block) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AccountsWithStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountsWithStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccountsWithStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Block != nil {
-		l = m.Block.Size()
-		n += 2 + l + sovKeymanager(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-func (m *SignRequest_AttestationData) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.ValidatingPublicKeys) > 0 {
+		for iNdEx := len(m.ValidatingPublicKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ValidatingPublicKeys[iNdEx])
+			copy(dAtA[i:], m.ValidatingPublicKeys[iNdEx])
+			i = encodeVarintKeymanager(dAtA, i, uint64(len(m.ValidatingPublicKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	var l int
-	_ = l
-	if m.AttestationData != nil {
-		l = m.AttestationData.Size()
-		n += 2 + l + sovKeymanager(uint64(l))
+	if m.Status != 0 {
+		i = encodeVarintKeymanager(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *SignRequest_AggregateAttestationAndProof) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *ReloadKeystoresResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReloadKeystoresResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReloadKeystoresResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Removed != 0 {
+		i = encodeVarintKeymanager(dAtA, i, uint64(m.Removed))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Added != 0 {
+		i = encodeVarintKeymanager(dAtA, i, uint64(m.Added))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListSupportedSigningTypesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSupportedSigningTypesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListSupportedSigningTypesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.SigningTypes) > 0 {
+		dAtA2 := make([]byte, len(m.SigningTypes)*10)
+		var j1 int
+		for _, num := range m.SigningTypes {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintKeymanager(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintKeymanager(dAtA []byte, offset int, v uint64) int {
+	offset -= sovKeymanager(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ListPublicKeysResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ValidatingPublicKeys) > 0 {
+		for _, b := range m.ValidatingPublicKeys {
+			l = len(b)
+			n += 1 + l + sovKeymanager(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovKeymanager(uint64(l))
+	}
+	l = len(m.SigningRoot)
+	if l > 0 {
+		n += 1 + l + sovKeymanager(uint64(l))
+	}
+	l = len(m.SignatureDomain)
+	if l > 0 {
+		n += 1 + l + sovKeymanager(uint64(l))
+	}
+	l = len(m.ForkVersion)
+	if l > 0 {
+		n += 1 + l + sovKeymanager(uint64(l))
+	}
+	l = len(m.GenesisValidatorsRoot)
+	if l > 0 {
+		n += 1 + l + sovKeymanager(uint64(l))
+	}
+	if m.Object != nil {
+		n += m.Object.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignRequest_Block) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 2 + l + sovKeymanager(uint64(l))
+	}
+	return n
+}
+func (m *SignRequest_AttestationData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AttestationData != nil {
+		l = m.AttestationData.Size()
+		n += 2 + l + sovKeymanager(uint64(l))
+	}
+	return n
+}
+func (m *SignRequest_AggregateAttestationAndProof) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -893,6 +1625,97 @@ func (m *SignResponse) Size() (n int) {
 	return n
 }
 
+func (m *ListAccountsByStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovKeymanager(uint64(m.Status))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListAccountsByStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Accounts) > 0 {
+		for _, e := range m.Accounts {
+			l = e.Size()
+			n += 1 + l + sovKeymanager(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AccountsWithStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovKeymanager(uint64(m.Status))
+	}
+	if len(m.ValidatingPublicKeys) > 0 {
+		for _, b := range m.ValidatingPublicKeys {
+			l = len(b)
+			n += 1 + l + sovKeymanager(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReloadKeystoresResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Added != 0 {
+		n += 1 + sovKeymanager(uint64(m.Added))
+	}
+	if m.Removed != 0 {
+		n += 1 + sovKeymanager(uint64(m.Removed))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListSupportedSigningTypesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SigningTypes) > 0 {
+		l = 0
+		for _, e := range m.SigningTypes {
+			l += sovKeymanager(uint64(e))
+		}
+		n += 1 + sovKeymanager(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovKeymanager(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1116,11 +1939,11 @@ func (m *SignRequest) Unmarshal(dAtA []byte) error {
 				m.SignatureDomain = []byte{}
 			}
 			iNdEx = postIndex
-		case 101:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ForkVersion", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowKeymanager
@@ -1130,32 +1953,31 @@ func (m *SignRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthKeymanager
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthKeymanager
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &v1alpha1.BeaconBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ForkVersion = append(m.ForkVersion[:0], dAtA[iNdEx:postIndex]...)
+			if m.ForkVersion == nil {
+				m.ForkVersion = []byte{}
 			}
-			m.Object = &SignRequest_Block{v}
 			iNdEx = postIndex
-		case 102:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AttestationData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GenesisValidatorsRoot", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowKeymanager
@@ -1165,30 +1987,29 @@ func (m *SignRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthKeymanager
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthKeymanager
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &v1alpha1.AttestationData{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.GenesisValidatorsRoot = append(m.GenesisValidatorsRoot[:0], dAtA[iNdEx:postIndex]...)
+			if m.GenesisValidatorsRoot == nil {
+				m.GenesisValidatorsRoot = []byte{}
 			}
-			m.Object = &SignRequest_AttestationData{v}
 			iNdEx = postIndex
-		case 103:
+		case 101:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AggregateAttestationAndProof", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1215,15 +2036,85 @@ func (m *SignRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &v1alpha1.AggregateAttestationAndProof{}
+			v := &v1alpha1.BeaconBlock{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Object = &SignRequest_AggregateAttestationAndProof{v}
+			m.Object = &SignRequest_Block{v}
 			iNdEx = postIndex
-		case 104:
+		case 102:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AttestationData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &v1alpha1.AttestationData{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Object = &SignRequest_AttestationData{v}
+			iNdEx = postIndex
+		case 103:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateAttestationAndProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &v1alpha1.AggregateAttestationAndProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Object = &SignRequest_AggregateAttestationAndProof{v}
+			iNdEx = postIndex
+		case 104:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1428,6 +2319,487 @@ func (m *SignResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ListAccountsByStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeymanager
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAccountsByStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAccountsByStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= v1alpha1.ValidatorStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeymanager(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAccountsByStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeymanager
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAccountsByStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAccountsByStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Accounts = append(m.Accounts, &AccountsWithStatus{})
+			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeymanager(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountsWithStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeymanager
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountsWithStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountsWithStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= v1alpha1.ValidatorStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatingPublicKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatingPublicKeys = append(m.ValidatingPublicKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ValidatingPublicKeys[len(m.ValidatingPublicKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeymanager(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReloadKeystoresResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeymanager
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReloadKeystoresResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReloadKeystoresResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Added", wireType)
+			}
+			m.Added = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Added |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			}
+			m.Removed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeymanager
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Removed |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeymanager(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListSupportedSigningTypesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeymanager
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListSupportedSigningTypesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListSupportedSigningTypesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v ListSupportedSigningTypesResponse_SigningType
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowKeymanager
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= ListSupportedSigningTypesResponse_SigningType(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.SigningTypes = append(m.SigningTypes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowKeymanager
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthKeymanager
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthKeymanager
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.SigningTypes) == 0 {
+					m.SigningTypes = make([]ListSupportedSigningTypesResponse_SigningType, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v ListSupportedSigningTypesResponse_SigningType
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowKeymanager
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= ListSupportedSigningTypesResponse_SigningType(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.SigningTypes = append(m.SigningTypes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field SigningTypes", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeymanager(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthKeymanager
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipKeymanager(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -23,13 +23,35 @@ var squareRootTable = map[uint64]uint64{
 }
 
 // IntegerSquareRoot defines a function that returns the
-// largest possible integer root of a number using go's standard library.
+// largest possible integer root of a number, with the integer
+// Newton's method of the spec. Unlike a float square root, it
+// does not lose precision for numbers near 2**64.
+//
+// Spec pseudocode definition:
+//  def integer_squareroot(n: uint64) -> uint64:
+//    """
+//    Return the largest integer ``x`` such that ``x**2 <= n``.
+//    """
+//    x = n
+//    y = (x + 1) // 2
+//    while y < x:
+//        x = y
+//        y = (x + n // x) // 2
+//    return x
 func IntegerSquareRoot(n uint64) uint64 {
 	if v, ok := squareRootTable[n]; ok {
 		return v
 	}
 
-	return uint64(math.Sqrt(float64(n)))
+	// (x + 1) / 2 is computed as x/2 + x%2, so that it does not
+	// overflow for n = 2**64 - 1.
+	x := n
+	y := x/2 + x%2
+	for y < x {
+		x = y
+		y = (x + n/x) / 2
+	}
+	return x
 }
 
 // CeilDiv8 divides the input number by 8
@@ -69,6 +69,21 @@ func TestIntegerSquareRoot(t *testing.T) {
 			number: 16,
 			root:   4,
 		},
+		// Perfect squares.
+		{number: 0, root: 0},
+		{number: 1, root: 1},
+		{number: 1e18, root: 1e9},
+		{number: 4294967295 * 4294967295, root: 4294967295},
+		// Non perfect squares are floored.
+		{number: 2, root: 1},
+		{number: 3, root: 1},
+		{number: 1023, root: 31},
+		{number: 1e18 - 1, root: 1e9 - 1},
+		// Boundary values near 2**64, where a float square root is off.
+		{number: 4294967295*4294967295 - 1, root: 4294967294},
+		{number: 4294967295*4294967295 + 1, root: 4294967295},
+		{number: math.MaxUint64 - 1, root: 4294967295},
+		{number: math.MaxUint64, root: 4294967295},
 	}
 
 	for _, testVals := range tt {
@@ -37,6 +37,46 @@ func (m *MockRemoteSignerClient) EXPECT() *MockRemoteSignerClientMockRecorder {
 	return m.recorder
 }
 
+// ListSupportedSigningTypes mocks base method
+func (m *MockRemoteSignerClient) ListSupportedSigningTypes(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*ethereum_validator_accounts_v2.ListSupportedSigningTypesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSupportedSigningTypes", varargs...)
+	ret0, _ := ret[0].(*ethereum_validator_accounts_v2.ListSupportedSigningTypesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSupportedSigningTypes indicates an expected call of ListSupportedSigningTypes
+func (mr *MockRemoteSignerClientMockRecorder) ListSupportedSigningTypes(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSupportedSigningTypes", reflect.TypeOf((*MockRemoteSignerClient)(nil).ListSupportedSigningTypes), varargs...)
+}
+
+// ListValidatingAccountsByStatus mocks base method
+func (m *MockRemoteSignerClient) ListValidatingAccountsByStatus(arg0 context.Context, arg1 *ethereum_validator_accounts_v2.ListAccountsByStatusRequest, arg2 ...grpc.CallOption) (*ethereum_validator_accounts_v2.ListAccountsByStatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListValidatingAccountsByStatus", varargs...)
+	ret0, _ := ret[0].(*ethereum_validator_accounts_v2.ListAccountsByStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListValidatingAccountsByStatus indicates an expected call of ListValidatingAccountsByStatus
+func (mr *MockRemoteSignerClientMockRecorder) ListValidatingAccountsByStatus(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListValidatingAccountsByStatus", reflect.TypeOf((*MockRemoteSignerClient)(nil).ListValidatingAccountsByStatus), varargs...)
+}
+
 // ListValidatingPublicKeys mocks base method
 func (m *MockRemoteSignerClient) ListValidatingPublicKeys(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*ethereum_validator_accounts_v2.ListPublicKeysResponse, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +97,26 @@ func (mr *MockRemoteSignerClientMockRecorder) ListValidatingPublicKeys(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListValidatingPublicKeys", reflect.TypeOf((*MockRemoteSignerClient)(nil).ListValidatingPublicKeys), varargs...)
 }
 
+// ReloadKeystores mocks base method
+func (m *MockRemoteSignerClient) ReloadKeystores(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*ethereum_validator_accounts_v2.ReloadKeystoresResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadKeystores", varargs...)
+	ret0, _ := ret[0].(*ethereum_validator_accounts_v2.ReloadKeystoresResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadKeystores indicates an expected call of ReloadKeystores
+func (mr *MockRemoteSignerClientMockRecorder) ReloadKeystores(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadKeystores", reflect.TypeOf((*MockRemoteSignerClient)(nil).ReloadKeystores), varargs...)
+}
+
 // Sign mocks base method
 func (m *MockRemoteSignerClient) Sign(arg0 context.Context, arg1 *ethereum_validator_accounts_v2.SignRequest, arg2 ...grpc.CallOption) (*ethereum_validator_accounts_v2.SignResponse, error) {
 	m.ctrl.T.Helper()
@@ -76,3 +136,23 @@ func (mr *MockRemoteSignerClientMockRecorder) Sign(arg0, arg1 interface{}, arg2
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockRemoteSignerClient)(nil).Sign), varargs...)
 }
+
+// SignStream mocks base method
+func (m *MockRemoteSignerClient) SignStream(arg0 context.Context, arg1 ...grpc.CallOption) (ethereum_validator_accounts_v2.RemoteSigner_SignStreamClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SignStream", varargs...)
+	ret0, _ := ret[0].(ethereum_validator_accounts_v2.RemoteSigner_SignStreamClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignStream indicates an expected call of SignStream
+func (mr *MockRemoteSignerClientMockRecorder) SignStream(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignStream", reflect.TypeOf((*MockRemoteSignerClient)(nil).SignStream), varargs...)
+}
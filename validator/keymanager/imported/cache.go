@@ -0,0 +1,63 @@
+package imported
+
+import (
+	"sync"
+	"time"
+)
+
+// keysGeneration is incremented whenever the keys cache or the disabled public
+// keys change, which invalidates any cached list of validating public keys.
+// It is guarded by the package lock.
+var keysGeneration uint64
+
+// publicKeysCache holds the validating public keys of a keymanager for up to
+// a TTL, as they are listed far more often than the keys change.
+type publicKeysCache struct {
+	sync.Mutex
+	ttl        time.Duration
+	keys       [][48]byte
+	generation uint64
+	expiresAt  time.Time
+}
+
+func newPublicKeysCache(ttl time.Duration) *publicKeysCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &publicKeysCache{ttl: ttl}
+}
+
+// get returns a copy of the cached public keys if they were cached for the
+// given keys generation and have not expired yet.
+func (c *publicKeysCache) get(generation uint64) ([][48]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.keys == nil || c.generation != generation || time.Now().After(c.expiresAt) {
+		return nil, false
+	}
+	keys := make([][48]byte, len(c.keys))
+	copy(keys, c.keys)
+	return keys, true
+}
+
+// put caches a copy of the public keys computed for the given keys generation.
+func (c *publicKeysCache) put(keys [][48]byte, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.keys = make([][48]byte, len(keys))
+	copy(c.keys, keys)
+	c.generation = generation
+	c.expiresAt = time.Now().Add(c.ttl)
+}
+
+// invalidatePublicKeysCaches marks all cached lists of validating public keys
+// as stale. This assumes that the package lock is already held for writing.
+func invalidatePublicKeysCaches() {
+	keysGeneration++
+}
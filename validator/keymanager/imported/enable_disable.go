@@ -21,6 +21,7 @@ func (dr *Keymanager) DisableAccounts(ctx context.Context, pubKeys [][]byte) err
 			dr.disabledPublicKeys[bytesutil.ToBytes48(pk)] = true
 		}
 	}
+	invalidatePublicKeysCaches()
 	return dr.rewriteDisabledKeysToDisk(ctx)
 }
 
@@ -34,6 +35,7 @@ func (dr *Keymanager) EnableAccounts(ctx context.Context, pubKeys [][]byte) erro
 	for _, pk := range pubKeys {
 		delete(dr.disabledPublicKeys, bytesutil.ToBytes48(pk))
 	}
+	invalidatePublicKeysCaches()
 	return dr.rewriteDisabledKeysToDisk(ctx)
 }
 
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -44,12 +46,17 @@ type Keymanager struct {
 	accountsStore       *accountStore
 	disabledPublicKeys  map[[48]byte]bool
 	accountsChangedFeed *event.Feed
+	publicKeysCache     *publicKeysCache
 }
 
 // SetupConfig includes configuration values for initializing
 // a keymanager, such as passwords, the wallet, and more.
 type SetupConfig struct {
 	Wallet iface.Wallet
+	// PublicKeysCacheTTL is how long the validating public keys are served from
+	// memory before being recomputed, unless accounts are imported, deleted,
+	// enabled or disabled in the meantime. Zero disables the cache.
+	PublicKeysCacheTTL time.Duration
 }
 
 // Defines a struct containing 1-to-1 corresponding
@@ -75,6 +82,7 @@ func ResetCaches() {
 	lock.Lock()
 	orderedPublicKeys = make([][48]byte, 0)
 	secretKeysCache = make(map[[48]byte]bls.SecretKey)
+	invalidatePublicKeysCaches()
 	lock.Unlock()
 }
 
@@ -85,6 +93,7 @@ func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 		accountsStore:       &accountStore{},
 		accountsChangedFeed: new(event.Feed),
 		disabledPublicKeys:  make(map[[48]byte]bool),
+		publicKeysCache:     newPublicKeysCache(cfg.PublicKeysCacheTTL),
 	}
 
 	if err := k.initializeAccountKeystore(ctx); err != nil {
@@ -117,6 +126,7 @@ func NewInteropKeymanager(_ context.Context, offset, numValidatorKeys uint64) (*
 		secretKeysCache[publicKey] = secretKeys[i]
 	}
 	orderedPublicKeys = pubKeys
+	invalidatePublicKeysCaches()
 	lock.Unlock()
 	return k, nil
 }
@@ -155,6 +165,7 @@ func (dr *Keymanager) DisabledPublicKeys() [][]byte {
 func (dr *Keymanager) initializeKeysCachesFromKeystore() error {
 	lock.Lock()
 	defer lock.Unlock()
+	invalidatePublicKeysCaches()
 	count := len(dr.accountsStore.PrivateKeys)
 	orderedPublicKeys = make([][48]byte, count)
 	secretKeysCache = make(map[[48]byte]bls.SecretKey, count)
@@ -222,6 +233,10 @@ func (dr *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][48]byte
 	defer span.End()
 
 	lock.RLock()
+	defer lock.RUnlock()
+	if cached, ok := dr.publicKeysCache.get(keysGeneration); ok {
+		return cached, nil
+	}
 	keys := orderedPublicKeys
 	result := make([][48]byte, 0)
 	for _, pk := range keys {
@@ -229,7 +244,7 @@ func (dr *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][48]byte
 			result = append(result, pk)
 		}
 	}
-	lock.RUnlock()
+	dr.publicKeysCache.put(result, keysGeneration)
 	return result, nil
 }
 
@@ -267,7 +282,9 @@ func (dr *Keymanager) FetchValidatingPrivateKeys(ctx context.Context) ([][32]byt
 	return privKeys, nil
 }
 
-// Sign signs a message using a validator key.
+// Sign signs a message using a validator key. When the request overrides the fork
+// version, the signing root is computed from the beacon chain object of the request
+// in the domain of that fork version rather than taken from the request.
 func (dr *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
 	ctx, span := trace.StartSpan(ctx, "keymanager.Sign")
 	defer span.End()
@@ -282,9 +299,78 @@ func (dr *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (b
 	if !ok {
 		return nil, errors.New("no signing key found in keys cache")
 	}
+	if len(req.ForkVersion) > 0 {
+		signingRoot, err := forkVersionSigningRoot(req)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute signing root for fork version")
+		}
+		return secretKey.Sign(signingRoot[:]), nil
+	}
 	return secretKey.Sign(req.SigningRoot), nil
 }
 
+// forkVersionSigningRoot computes the signing root of the beacon chain object of a
+// sign request, in the domain of the domain type of its signature domain and of its
+// fork version and genesis validators root. Both of them are required to be set
+// with their exact length, as ComputeDomain would otherwise silently pad them.
+func forkVersionSigningRoot(req *validatorpb.SignRequest) ([32]byte, error) {
+	if len(req.ForkVersion) != 4 {
+		return [32]byte{}, errors.Errorf("fork version must be 4 bytes, received %d", len(req.ForkVersion))
+	}
+	if len(req.GenesisValidatorsRoot) != 32 {
+		return [32]byte{}, errors.Errorf("genesis validators root must be 32 bytes, received %d", len(req.GenesisValidatorsRoot))
+	}
+	if len(req.SignatureDomain) < helpers.DomainByteLength {
+		return [32]byte{}, errors.New("signature domain does not hold a domain type")
+	}
+	domain, err := helpers.ComputeDomain(
+		bytesutil.ToBytes4(req.SignatureDomain), req.ForkVersion, req.GenesisValidatorsRoot,
+	)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	var object interface{}
+	switch obj := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		if obj.Block != nil {
+			object = obj.Block
+		}
+	case *validatorpb.SignRequest_AttestationData:
+		if obj.AttestationData != nil {
+			object = obj.AttestationData
+		}
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		if obj.AggregateAttestationAndProof != nil {
+			object = obj.AggregateAttestationAndProof
+		}
+	case *validatorpb.SignRequest_Exit:
+		if obj.Exit != nil {
+			object = obj.Exit
+		}
+	case *validatorpb.SignRequest_Slot:
+		object = obj.Slot
+	case *validatorpb.SignRequest_Epoch:
+		object = obj.Epoch
+	}
+	if object == nil {
+		return [32]byte{}, errors.New("no beacon chain object in request")
+	}
+	return helpers.ComputeSigningRoot(object, domain)
+}
+
+// ListSupportedSigningTypes returns the types of data the keymanager supports signing.
+// As Sign only signs the signing root of a request, every type of data is supported.
+func (dr *Keymanager) ListSupportedSigningTypes(_ context.Context) ([]validatorpb.ListSupportedSigningTypesResponse_SigningType, error) {
+	return []validatorpb.ListSupportedSigningTypesResponse_SigningType{
+		validatorpb.ListSupportedSigningTypesResponse_BLOCK,
+		validatorpb.ListSupportedSigningTypesResponse_ATTESTATION_DATA,
+		validatorpb.ListSupportedSigningTypesResponse_AGGREGATE_ATTESTATION_AND_PROOF,
+		validatorpb.ListSupportedSigningTypesResponse_EXIT,
+		validatorpb.ListSupportedSigningTypesResponse_SLOT,
+		validatorpb.ListSupportedSigningTypesResponse_EPOCH,
+	}, nil
+}
+
 func (dr *Keymanager) initializeAccountKeystore(ctx context.Context) error {
 	encoded, err := dr.wallet.ReadFileAtPath(ctx, AccountsPath, AccountsKeystoreFileName)
 	if err != nil && strings.Contains(err.Error(), "no files found") {
@@ -330,6 +416,7 @@ func (dr *Keymanager) initializeAccountKeystore(ctx context.Context) error {
 		}
 		dr.disabledPublicKeys[bytesutil.ToBytes48(pubKeyBytes)] = true
 	}
+	invalidatePublicKeysCaches()
 	lock.Unlock()
 	err = dr.initializeKeysCachesFromKeystore()
 	if err != nil {
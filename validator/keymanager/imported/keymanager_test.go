@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	mock "github.com/prysmaticlabs/prysm/validator/accounts/testing"
@@ -108,6 +111,49 @@ func TestImportedKeymanager_FetchValidatingPublicKeys(t *testing.T) {
 	}
 }
 
+func TestImportedKeymanager_FetchValidatingPublicKeys_Cached(t *testing.T) {
+	password := "secretPassw0rd$1999"
+	wallet := &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}
+	dr := &Keymanager{
+		wallet:             wallet,
+		accountsStore:      &accountStore{},
+		disabledPublicKeys: make(map[[48]byte]bool),
+		publicKeysCache:    newPublicKeysCache(time.Hour),
+	}
+	ctx := context.Background()
+	require.NoError(t, dr.ImportKeystores(ctx, []*keymanager.Keystore{createRandomKeystore(t, password)}, password))
+	keys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+
+	// Repeated calls are served from the cache.
+	lock.Lock()
+	orderedPublicKeys = nil
+	lock.Unlock()
+	cached, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, keys, cached)
+
+	// Importing a key refreshes the list without waiting for the TTL.
+	require.NoError(t, dr.ImportKeystores(ctx, []*keymanager.Keystore{createRandomKeystore(t, password)}, password))
+	keys, err = dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+
+	// So do disabling and deleting keys.
+	require.NoError(t, dr.DisableAccounts(ctx, [][]byte{keys[0][:]}))
+	disabled, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, keys[1:], disabled)
+	require.NoError(t, dr.DeleteAccounts(ctx, [][]byte{keys[1][:]}))
+	remaining, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(remaining))
+}
+
 func TestImportedKeymanager_FetchAllValidatingPublicKeys(t *testing.T) {
 	password := "secretPassw0rd$1999"
 	wallet := &mock.Wallet{
@@ -262,3 +308,80 @@ func TestImportedKeymanager_Sign_NoPublicKeyInCache(t *testing.T) {
 	_, err := dr.Sign(context.Background(), req)
 	assert.ErrorContains(t, "no signing key found in keys cache", err)
 }
+
+func TestImportedKeymanager_Sign_ForkVersion(t *testing.T) {
+	secretKeysCache = make(map[[48]byte]bls.SecretKey)
+	secretKey, err := bls.RandKey()
+	require.NoError(t, err)
+	publicKey := secretKey.PublicKey().Marshal()
+	secretKeysCache[bytesutil.ToBytes48(publicKey)] = secretKey
+	dr := &Keymanager{}
+	ctx := context.Background()
+
+	domainType := params.BeaconConfig().DomainRandao
+	genesisValidatorsRoot := bytesutil.PadTo([]byte("genesis"), 32)
+	signatureDomain, err := helpers.ComputeDomain(domainType, []byte{0, 0, 0, 0}, genesisValidatorsRoot)
+	require.NoError(t, err)
+	epoch := uint64(10)
+
+	sign := func(forkVersion []byte) bls.Signature {
+		sig, err := dr.Sign(ctx, &validatorpb.SignRequest{
+			PublicKey:             publicKey,
+			SignatureDomain:       signatureDomain,
+			ForkVersion:           forkVersion,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+			Object:                &validatorpb.SignRequest_Epoch{Epoch: epoch},
+		})
+		require.NoError(t, err)
+		domain, err := helpers.ComputeDomain(domainType, forkVersion, genesisValidatorsRoot)
+		require.NoError(t, err)
+		signingRoot, err := helpers.ComputeSigningRoot(epoch, domain)
+		require.NoError(t, err)
+		assert.Equal(t, true, sig.Verify(secretKey.PublicKey(), signingRoot[:]), "Expected sig to verify for fork version %#x", forkVersion)
+		return sig
+	}
+	first := sign([]byte{1, 0, 0, 0})
+	second := sign([]byte{2, 0, 0, 0})
+	assert.DeepNotEqual(t, first.Marshal(), second.Marshal())
+
+	// The object to sign is required to compute the signing root.
+	_, err = dr.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:             publicKey,
+		SignatureDomain:       signatureDomain,
+		ForkVersion:           []byte{1, 0, 0, 0},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	})
+	assert.ErrorContains(t, "no beacon chain object in request", err)
+
+	// The genesis validators root is required along with the fork version.
+	_, err = dr.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:       publicKey,
+		SignatureDomain: signatureDomain,
+		ForkVersion:     []byte{1, 0, 0, 0},
+		Object:          &validatorpb.SignRequest_Epoch{Epoch: epoch},
+	})
+	assert.ErrorContains(t, "genesis validators root must be 32 bytes, received 0", err)
+
+	// The fork version is not padded to 4 bytes.
+	_, err = dr.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:             publicKey,
+		SignatureDomain:       signatureDomain,
+		ForkVersion:           []byte{1, 0},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		Object:                &validatorpb.SignRequest_Epoch{Epoch: epoch},
+	})
+	assert.ErrorContains(t, "fork version must be 4 bytes, received 2", err)
+}
+
+func TestImportedKeymanager_ListSupportedSigningTypes(t *testing.T) {
+	dr := &Keymanager{}
+	signingTypes, err := dr.ListSupportedSigningTypes(context.Background())
+	require.NoError(t, err)
+	supported := make(map[validatorpb.ListSupportedSigningTypesResponse_SigningType]bool)
+	for _, signingType := range signingTypes {
+		supported[signingType] = true
+	}
+	assert.Equal(t, true, supported[validatorpb.ListSupportedSigningTypesResponse_BLOCK])
+	assert.Equal(t, true, supported[validatorpb.ListSupportedSigningTypesResponse_ATTESTATION_DATA])
+	assert.Equal(t, false, supported[validatorpb.ListSupportedSigningTypesResponse_UNKNOWN])
+}
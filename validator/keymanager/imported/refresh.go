@@ -130,6 +130,7 @@ func (dr *Keymanager) reloadAccountsFromKeystore(keystore *AccountsKeystoreRepre
 		}
 		dr.disabledPublicKeys[bytesutil.ToBytes48(pubKeyBytes)] = true
 	}
+	invalidatePublicKeysCaches()
 	lock.Unlock()
 	dr.accountsStore = newAccountsStore
 	if err := dr.initializeKeysCachesFromKeystore(); err != nil {
@@ -139,3 +140,44 @@ func (dr *Keymanager) reloadAccountsFromKeystore(keystore *AccountsKeystoreRepre
 	dr.accountsChangedFeed.Send(pubKeys)
 	return nil
 }
+
+// ReloadKeystores rescans the accounts keystore file in the wallet and reloads its
+// keys into the keymanager, such that keys imported by other processes can be used
+// without a restart. It returns the number of validating public keys which were
+// added and removed by the reload.
+func (dr *Keymanager) ReloadKeystores(ctx context.Context) (added, removed uint64, err error) {
+	encoded, err := dr.wallet.ReadFileAtPath(ctx, AccountsPath, AccountsKeystoreFileName)
+	if err != nil && strings.Contains(err.Error(), "no files found") {
+		// If there is no keystore file yet, there are no keys to reload.
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, errors.Wrapf(err, "could not read keystore file for accounts %s", AccountsKeystoreFileName)
+	}
+	keystoreFile := &AccountsKeystoreRepresentation{}
+	if err := json.Unmarshal(encoded, keystoreFile); err != nil {
+		return 0, 0, errors.Wrapf(err, "could not decode keystore file for accounts %s", AccountsKeystoreFileName)
+	}
+	oldKeys, err := dr.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not fetch validating public keys")
+	}
+	if err := dr.reloadAccountsFromKeystore(keystoreFile); err != nil {
+		return 0, 0, errors.Wrap(err, "could not reload accounts from keystore")
+	}
+	newKeys, err := dr.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not fetch validating public keys")
+	}
+	oldSet := make(map[[48]byte]bool, len(oldKeys))
+	for _, pubKey := range oldKeys {
+		oldSet[pubKey] = true
+	}
+	for _, pubKey := range newKeys {
+		if oldSet[pubKey] {
+			delete(oldSet, pubKey)
+		} else {
+			added++
+		}
+	}
+	return added, uint64(len(oldSet)), nil
+}
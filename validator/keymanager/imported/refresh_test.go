@@ -91,3 +91,62 @@ func TestImportedKeymanager_reloadAccountsFromKeystore(t *testing.T) {
 	require.Equal(t, numAccounts, len(dr.accountsStore.PrivateKeys))
 	assert.DeepEqual(t, dr.accountsStore.PublicKeys[0], pubKeys[0])
 }
+
+func TestImportedKeymanager_ReloadKeystores(t *testing.T) {
+	ResetCaches()
+	ctx := context.Background()
+	wallet := &mock.Wallet{
+		Files:            make(map[string]map[string][]byte),
+		AccountPasswords: make(map[string]string),
+		WalletPassword:   "Passw03rdz293**%#2",
+	}
+	dr := &Keymanager{
+		wallet:              wallet,
+		accountsStore:       &accountStore{},
+		disabledPublicKeys:  make(map[[48]byte]bool),
+		accountsChangedFeed: new(event.Feed),
+	}
+
+	// Nothing to reload without a keystore file.
+	added, removed, err := dr.ReloadKeystores(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), added)
+	assert.Equal(t, uint64(0), removed)
+
+	privKeys := make([][]byte, 4)
+	pubKeys := make([][]byte, 4)
+	for i := range privKeys {
+		privKey, err := bls.RandKey()
+		require.NoError(t, err)
+		privKeys[i] = privKey.Marshal()
+		pubKeys[i] = privKey.PublicKey().Marshal()
+	}
+	writeKeystore := func(privKeys, pubKeys [][]byte) {
+		// The keystore is written by another keymanager, as would happen
+		// when importing keys from a separate process.
+		store, err := (&Keymanager{wallet: wallet}).CreateAccountsKeystore(ctx, privKeys, pubKeys)
+		require.NoError(t, err)
+		encoded, err := json.Marshal(store)
+		require.NoError(t, err)
+		require.NoError(t, wallet.WriteFileAtPath(ctx, AccountsPath, AccountsKeystoreFileName, encoded))
+	}
+
+	writeKeystore(privKeys[:2], pubKeys[:2])
+	added, removed, err = dr.ReloadKeystores(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), added)
+	assert.Equal(t, uint64(0), removed)
+
+	writeKeystore(privKeys[1:], pubKeys[1:])
+	added, removed, err = dr.ReloadKeystores(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), added)
+	assert.Equal(t, uint64(1), removed)
+
+	keys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(keys))
+	for i, key := range keys {
+		assert.Equal(t, bytesutil.ToBytes48(pubKeys[i+1]), key)
+	}
+}
@@ -0,0 +1,81 @@
+package remote
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reasons attached as structured error details to a failed signing request,
+// allowing clients to distinguish between the possible causes of the failure.
+const (
+	ReasonUnknownPublicKey     = "UNKNOWN_PUBLIC_KEY"
+	ReasonMalformedSigningRoot = "MALFORMED_SIGNING_ROOT"
+	ReasonSlashableRequest     = "SLASHABLE_REQUEST"
+)
+
+// signErrorDomain scopes the error reasons defined above.
+const signErrorDomain = "remote-signer.prysm"
+
+var (
+	// ErrUnknownPublicKey defines a failure from the remote server when
+	// the requested public key is not managed by it.
+	ErrUnknownPublicKey = errors.New("public key is unknown to the remote server")
+	// ErrMalformedSigningRoot defines a failure from the remote server when
+	// the provided signing root could not be parsed.
+	ErrMalformedSigningRoot = errors.New("signing root is malformed")
+	// ErrSlashableRequest defines a failure from the remote server when
+	// signing the requested object could lead to a slashing.
+	ErrSlashableRequest = errors.New("signing request is slashable")
+)
+
+// NewSignError returns a gRPC status error for a failed signing request with
+// the given reason attached as structured error details. Remote signer servers
+// should use it so that clients, including those behind the gRPC gateway, can
+// branch on the cause of the failure.
+func NewSignError(reason, msg string) error {
+	var code codes.Code
+	switch reason {
+	case ReasonUnknownPublicKey:
+		code = codes.NotFound
+	case ReasonMalformedSigningRoot:
+		code = codes.InvalidArgument
+	case ReasonSlashableRequest:
+		code = codes.FailedPrecondition
+	default:
+		code = codes.Unknown
+	}
+	st, err := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: signErrorDomain,
+	})
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// signError converts a gRPC error returned by the remote server into one of the
+// typed signing errors if it carries a known reason in its error details.
+func signError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != signErrorDomain {
+			continue
+		}
+		switch info.Reason {
+		case ReasonUnknownPublicKey:
+			return errors.Wrap(ErrUnknownPublicKey, st.Message())
+		case ReasonMalformedSigningRoot:
+			return errors.Wrap(ErrMalformedSigningRoot, st.Message())
+		case ReasonSlashableRequest:
+			return errors.Wrap(ErrSlashableRequest, st.Message())
+		}
+	}
+	return err
+}
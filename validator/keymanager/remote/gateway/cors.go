@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/rs/cors"
+)
+
+// WithAllowedOrigins sets the CORS headers on the responses of the remote signer
+// endpoints for requests from the given origins, and answers their preflight
+// OPTIONS requests, such that browser based dashboards can call the gateway.
+// Requests from other origins are served without CORS headers.
+func WithAllowedOrigins(origins ...string) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.allowedOrigins = append(cfg.allowedOrigins, origins...)
+	}
+}
+
+func newCors(allowedOrigins []string) *cors.Cors {
+	if len(allowedOrigins) == 0 {
+		return nil
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{http.MethodPost, http.MethodGet, http.MethodOptions},
+		MaxAge:         600,
+		AllowedHeaders: []string{"*"},
+	})
+}
+
+// corsHandlerFunc sets the CORS headers of the request before serving it with
+// the given handler.
+func corsHandlerFunc(c *cors.Cors, h runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		c.HandlerFunc(w, r)
+		h(w, r, pathParams)
+	}
+}
+
+// corsPreflightHandlerFunc answers the preflight requests of an endpoint.
+func corsPreflightHandlerFunc(c *cors.Cors) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		c.HandlerFunc(w, r)
+		w.WriteHeader(http.StatusOK)
+	}
+}
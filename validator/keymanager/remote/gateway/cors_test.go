@@ -0,0 +1,54 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestWithAllowedOrigins(t *testing.T) {
+	signer := &countingRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, signer, WithAllowedOrigins("https://dashboard.example"),
+	))
+
+	serve := func(method, path, origin string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Origin", origin)
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodPost, SignPath+"?slot=1", "https://dashboard.example")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://dashboard.example", rec.Header().Get("Access-Control-Allow-Origin"))
+	rec = serve(http.MethodGet, ListPublicKeysPath, "https://dashboard.example")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://dashboard.example", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = serve(http.MethodPost, SignPath+"?slot=1", "https://evil.example")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, 2, signer.signs)
+
+	// Preflight requests are answered without reaching the remote signer.
+	for _, path := range []string{SignPath, ListPublicKeysPath} {
+		rec = serve(http.MethodOptions, path, "https://dashboard.example")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://dashboard.example", rec.Header().Get("Access-Control-Allow-Origin"))
+		rec = serve(http.MethodOptions, path, "https://evil.example")
+		assert.Equal(t, "", rec.Header().Get("Access-Control-Allow-Origin"))
+	}
+	assert.Equal(t, 2, signer.signs)
+	assert.Equal(t, 1, signer.listings)
+}
@@ -0,0 +1,128 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+)
+
+// BackoffConfig defines the exponential backoff used when dialing the
+// remote signer, both for the initial dial and when reconnecting after
+// the connection to the remote signer is broken. Unset fields, other than
+// MaxAttempts, take their value from DefaultBackoffConfig.
+type BackoffConfig struct {
+	// BaseDelay is the delay before retrying after the first failure.
+	BaseDelay time.Duration
+	// Multiplier is the factor the delay is multiplied with after each failure.
+	Multiplier float64
+	// MaxDelay is the upper bound of the delay between retries.
+	MaxDelay time.Duration
+	// DialTimeout bounds each attempt at establishing a connection.
+	DialTimeout time.Duration
+	// MaxAttempts is the number of initial dial attempts before giving up,
+	// where zero means retrying until the context is done.
+	MaxAttempts int
+}
+
+// DefaultBackoffConfig is the backoff used when none is specified.
+var DefaultBackoffConfig = BackoffConfig{
+	BaseDelay:   time.Second,
+	Multiplier:  1.6,
+	MaxDelay:    30 * time.Second,
+	DialTimeout: 5 * time.Second,
+	MaxAttempts: 5,
+}
+
+// withDefaults returns the config with its unset fields taken from DefaultBackoffConfig,
+// as a zero dial timeout would fail every attempt and a zero multiplier would retry
+// without any delay.
+func (cfg BackoffConfig) withDefaults() BackoffConfig {
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = DefaultBackoffConfig.BaseDelay
+	}
+	if cfg.Multiplier <= 0 {
+		cfg.Multiplier = DefaultBackoffConfig.Multiplier
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = DefaultBackoffConfig.MaxDelay
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = DefaultBackoffConfig.DialTimeout
+	}
+	return cfg
+}
+
+// RegisterRemoteSignerHandlerFromEndpointWithBackoff is the same as the generated
+// RegisterRemoteSignerHandlerFromEndpoint, but retries dialing "endpoint" with an
+// exponential backoff instead of giving up on transient failures. A broken connection
+// is re-dialed with the same backoff, and requests wait for the connection to be ready
+// again rather than failing, so long-lived gateways survive a remote signer restart.
+// Sign requests are recorded in the sign metrics, and requests without a beacon chain
+// object are rejected, as with the versioned handlers when the sign/root endpoint is
+// disabled.
+func RegisterRemoteSignerHandlerFromEndpointWithBackoff(
+	ctx context.Context,
+	mux *runtime.ServeMux,
+	endpoint string,
+	opts []grpc.DialOption,
+	cfg BackoffConfig,
+) error {
+	conn, err := dialWithBackoff(ctx, endpoint, opts, cfg)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		if cerr := conn.Close(); cerr != nil {
+			log.WithError(cerr).Errorf("Failed to close conn to %s", endpoint)
+		}
+	}()
+	client := wrapRemoteSignerClient(pb.NewRemoteSignerClient(conn), &registerConfig{})
+	return pb.RegisterRemoteSignerHandlerClient(ctx, mux, client)
+}
+
+// dialWithBackoff blocks until a connection to the endpoint is established,
+// retrying failed attempts with an exponential backoff.
+func dialWithBackoff(ctx context.Context, endpoint string, opts []grpc.DialOption, cfg BackoffConfig) (*grpc.ClientConn, error) {
+	cfg = cfg.withDefaults()
+	opts = append(opts,
+		grpc.WithBlock(),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  cfg.BaseDelay,
+				Multiplier: cfg.Multiplier,
+				Jitter:     backoff.DefaultConfig.Jitter,
+				MaxDelay:   cfg.MaxDelay,
+			},
+			MinConnectTimeout: cfg.DialTimeout,
+		}),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	)
+	delay := cfg.BaseDelay
+	for attempt := 1; ; attempt++ {
+		dialCtx, cancel := context.WithTimeout(ctx, cfg.DialTimeout)
+		conn, err := grpc.DialContext(dialCtx, endpoint, opts...)
+		cancel()
+		if err == nil {
+			return conn, nil
+		}
+		if cfg.MaxAttempts > 0 && attempt >= cfg.MaxAttempts {
+			return nil, errors.Wrapf(err, "could not dial %s after %d attempts", endpoint, attempt)
+		}
+		log.WithError(err).WithField("attempt", attempt).Debugf("Could not dial %s, retrying in %v", endpoint, delay)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = time.Duration(float64(delay) * cfg.Multiplier)
+		if delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+		}
+	}
+}
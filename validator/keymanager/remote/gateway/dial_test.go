@@ -0,0 +1,148 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type listingRemoteSigner struct {
+	pb.UnimplementedRemoteSignerServer
+	keys [][]byte
+}
+
+func (s *listingRemoteSigner) ListValidatingPublicKeys(_ context.Context, _ *empty.Empty) (*pb.ListPublicKeysResponse, error) {
+	return &pb.ListPublicKeysResponse{ValidatingPublicKeys: s.keys}, nil
+}
+
+// controllableDialer connects to an in-memory listener, failing a
+// configurable number of attempts first.
+type controllableDialer struct {
+	lock     sync.Mutex
+	lis      *bufconn.Listener
+	failures int
+	attempts int
+}
+
+func (d *controllableDialer) dial(_ context.Context, _ string) (net.Conn, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.attempts++
+	if d.failures > 0 {
+		d.failures--
+		return nil, errors.New("connection refused")
+	}
+	return d.lis.Dial()
+}
+
+func (d *controllableDialer) serve(t *testing.T) *grpc.Server {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterRemoteSignerServer(server, &listingRemoteSigner{keys: [][]byte{make([]byte, 48)}})
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			t.Log(err)
+		}
+	}()
+	d.lock.Lock()
+	d.lis = lis
+	d.lock.Unlock()
+	return server
+}
+
+func TestRegisterRemoteSignerHandlerFromEndpointWithBackoff_Reconnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := &controllableDialer{failures: 2}
+	server := d.serve(t)
+
+	mux := runtime.NewServeMux()
+	cfg := BackoffConfig{
+		BaseDelay:   10 * time.Millisecond,
+		Multiplier:  1.6,
+		MaxDelay:    50 * time.Millisecond,
+		DialTimeout: time.Second,
+		MaxAttempts: 3,
+	}
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithContextDialer(d.dial)}
+	require.NoError(t, RegisterRemoteSignerHandlerFromEndpointWithBackoff(ctx, mux, "bufnet", opts, cfg))
+	d.lock.Lock()
+	assert.Equal(t, true, d.attempts >= 3, "Expected failed dials to be retried")
+	d.lock.Unlock()
+
+	listKeys := func() int {
+		reqCtx, reqCancel := context.WithTimeout(ctx, 5*time.Second)
+		defer reqCancel()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/accounts/v2/remote/accounts", nil).WithContext(reqCtx)
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	listingsBefore := testutil.ToFloat64(listKeysRequestsTotal)
+	assert.Equal(t, http.StatusOK, listKeys())
+	assert.Equal(t, listingsBefore+1, testutil.ToFloat64(listKeysRequestsTotal))
+
+	// Drop the connection by restarting the remote signer on a new listener.
+	server.Stop()
+	server = d.serve(t)
+	defer server.Stop()
+	assert.Equal(t, http.StatusOK, listKeys())
+}
+
+func TestRegisterRemoteSignerHandlerFromEndpointWithBackoff_GivesUp(t *testing.T) {
+	d := &controllableDialer{failures: 1000, lis: bufconn.Listen(1024)}
+	cfg := BackoffConfig{
+		BaseDelay:   time.Millisecond,
+		Multiplier:  1.6,
+		MaxDelay:    time.Millisecond,
+		DialTimeout: 20 * time.Millisecond,
+		MaxAttempts: 2,
+	}
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithContextDialer(d.dial)}
+	err := RegisterRemoteSignerHandlerFromEndpointWithBackoff(context.Background(), runtime.NewServeMux(), "bufnet", opts, cfg)
+	assert.ErrorContains(t, "after 2 attempts", err)
+}
+
+func TestBackoffConfig_WithDefaults(t *testing.T) {
+	assert.DeepEqual(t, BackoffConfig{
+		BaseDelay:   DefaultBackoffConfig.BaseDelay,
+		Multiplier:  DefaultBackoffConfig.Multiplier,
+		MaxDelay:    DefaultBackoffConfig.MaxDelay,
+		DialTimeout: DefaultBackoffConfig.DialTimeout,
+	}, BackoffConfig{}.withDefaults())
+
+	cfg := BackoffConfig{
+		BaseDelay:   time.Millisecond,
+		Multiplier:  2,
+		MaxDelay:    time.Second,
+		DialTimeout: time.Minute,
+		MaxAttempts: 3,
+	}
+	assert.DeepEqual(t, cfg, cfg.withDefaults())
+}
+
+func TestRegisterRemoteSignerHandlerFromEndpointWithBackoff_ZeroConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := &controllableDialer{}
+	server := d.serve(t)
+	defer server.Stop()
+
+	// A zero dial timeout would fail every attempt if it was used as is.
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithContextDialer(d.dial)}
+	require.NoError(t, RegisterRemoteSignerHandlerFromEndpointWithBackoff(ctx, runtime.NewServeMux(), "bufnet", opts, BackoffConfig{MaxAttempts: 1}))
+}
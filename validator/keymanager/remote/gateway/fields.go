@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+)
+
+// fieldsQueryParameter is the query parameter listing the response fields to
+// return, such as ?fields=validating_public_keys. It is only honored by the
+// handlers of RegisterVersionedRemoteSignerHandler; the generated
+// RegisterRemoteSignerHandler ignores it and always returns the full response.
+const fieldsQueryParameter = "fields"
+
+// requestedFields returns the fields listed in the fields query parameter of the
+// request, which may be comma separated or repeated. It returns nil when the
+// parameter is absent, in which case the full response is returned.
+func requestedFields(req *http.Request) map[string]bool {
+	values, ok := req.URL.Query()[fieldsQueryParameter]
+	if !ok {
+		return nil
+	}
+	fields := make(map[string]bool)
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields[field] = true
+			}
+		}
+	}
+	return fields
+}
+
+// fieldsMarshaler projects the JSON responses of the wrapped marshaler to the
+// requested top level fields. Responses of any other content type are left as is.
+type fieldsMarshaler struct {
+	runtime.Marshaler
+	fields map[string]bool
+}
+
+// Marshal marshals v, keeping only the requested fields of the JSON object.
+func (m *fieldsMarshaler) Marshal(v interface{}) ([]byte, error) {
+	enc, err := m.Marshaler.Marshal(v)
+	if err != nil || m.ContentType() != "application/json" {
+		return enc, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(enc, &obj); err != nil {
+		return nil, err
+	}
+	for field := range obj {
+		if !m.fields[field] {
+			delete(obj, field)
+		}
+	}
+	return json.Marshal(obj)
+}
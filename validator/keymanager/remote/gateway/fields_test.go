@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+)
+
+type keysRemoteSigner struct {
+	pb.RemoteSignerClient
+	keys [][]byte
+}
+
+func (k *keysRemoteSigner) ListValidatingPublicKeys(_ context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*pb.ListPublicKeysResponse, error) {
+	return &pb.ListPublicKeysResponse{ValidatingPublicKeys: k.keys}, nil
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient_Fields(t *testing.T) {
+	mux := runtime.NewServeMux()
+	client := &keysRemoteSigner{keys: [][]byte{{1, 2, 3}}}
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, client))
+
+	listKeys := func(query string) map[string]json.RawMessage {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ListPublicKeysPath+query, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var obj map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &obj))
+		return obj
+	}
+
+	_, ok := listKeys("")["validating_public_keys"]
+	assert.Equal(t, true, ok, "Expected the full response without a fields parameter")
+	_, ok = listKeys("?fields=validating_public_keys")["validating_public_keys"]
+	assert.Equal(t, true, ok, "Expected the requested field to be returned")
+
+	obj := listKeys("?fields=status,other")
+	_, ok = obj["validating_public_keys"]
+	assert.Equal(t, false, ok, "Expected the omitted field to be absent")
+	assert.Equal(t, 0, len(obj))
+}
+
+func TestRegisterRemoteSignerHandlerClient_IgnoresFields(t *testing.T) {
+	mux := runtime.NewServeMux()
+	client := &keysRemoteSigner{keys: [][]byte{{1, 2, 3}}}
+	require.NoError(t, pb.RegisterRemoteSignerHandlerClient(context.Background(), mux, client))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ListPublicKeysPath+"?fields=status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var obj map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &obj))
+	_, ok := obj["validating_public_keys"]
+	assert.Equal(t, true, ok, "Expected the generated handler to return the full response")
+}
@@ -0,0 +1,128 @@
+package gateway
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"google.golang.org/grpc"
+)
+
+// NewGzipHandler registers the remote signer handlers on a new gateway mux,
+// forwarding requests to conn, and returns the mux wrapped with GzipHandler. Sign
+// requests are recorded in the sign metrics, and requests without a beacon chain
+// object are rejected, as with the versioned handlers when the sign/root endpoint
+// is disabled.
+func NewGzipHandler(ctx context.Context, conn *grpc.ClientConn, opts ...runtime.ServeMuxOption) (http.Handler, error) {
+	mux := runtime.NewServeMux(opts...)
+	client := wrapRemoteSignerClient(pb.NewRemoteSignerClient(conn), &registerConfig{})
+	if err := pb.RegisterRemoteSignerHandlerClient(ctx, mux, client); err != nil {
+		return nil, err
+	}
+	return GzipHandler(mux), nil
+}
+
+// GzipHandler compresses the responses of the wrapped handler with gzip for
+// requests which accept it, as indicated by their Accept-Encoding header.
+func GzipHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer func() {
+			if err := gw.close(); err != nil {
+				log.WithError(err).Error("Could not close gzip writer")
+			}
+		}()
+		h.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip returns true when gzip is listed in the Accept-Encoding header
+// of the request and not explicitly refused with a zero quality value.
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		parts := strings.Split(encoding, ";")
+		if strings.TrimSpace(parts[0]) != "gzip" {
+			continue
+		}
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil && q == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter writes the response body through a gzip writer. The status
+// code is held back until the first write of the body, so that responses without
+// a body are sent as is, without a Content-Encoding header or gzip framing.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	// gz is only created by the first write of the body.
+	gz   *gzip.Writer
+	code int
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.gz == nil && w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.gz == nil {
+		if len(b) == 0 {
+			return 0, nil
+		}
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.ResponseWriter.WriteHeader(w.statusCode())
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	return w.gz.Write(b)
+}
+
+// Flush flushes the compressed data written so far, which is needed by
+// streaming gateway responses. Nothing is flushed before the body is written.
+func (w *gzipResponseWriter) Flush() {
+	if w.gz == nil {
+		return
+	}
+	if err := w.gz.Flush(); err != nil {
+		log.WithError(err).Error("Could not flush gzip writer")
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// close completes the gzip stream, or sends the held back status code when no
+// body was written.
+func (w *gzipResponseWriter) close() error {
+	if w.gz == nil {
+		w.ResponseWriter.WriteHeader(w.statusCode())
+		return nil
+	}
+	return w.gz.Close()
+}
+
+func (w *gzipResponseWriter) statusCode() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+	return w.code
+}
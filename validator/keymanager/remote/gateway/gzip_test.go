@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+)
+
+func TestNewGzipHandler_CompressesListResponses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := &controllableDialer{}
+	server := d.serve(t)
+	defer server.Stop()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(d.dial))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	h, err := NewGzipHandler(ctx, conn)
+	require.NoError(t, err)
+	listingsBefore := testutil.ToFloat64(listKeysRequestsTotal)
+
+	listKeys := func(acceptEncoding string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/accounts/v2/remote/accounts", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		h.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec
+	}
+	decode := func(body []byte) [][]byte {
+		resp := &pb.ListPublicKeysResponse{}
+		require.NoError(t, (&runtime.JSONPb{OrigName: true}).Unmarshal(body, resp))
+		return resp.ValidatingPublicKeys
+	}
+
+	plain := listKeys("")
+	assert.Equal(t, "", plain.Header().Get("Content-Encoding"))
+	want := decode(plain.Body.Bytes())
+	require.Equal(t, 1, len(want))
+
+	compressed := listKeys("deflate, gzip;q=0.8")
+	assert.Equal(t, "gzip", compressed.Header().Get("Content-Encoding"))
+	r, err := gzip.NewReader(compressed.Body)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, decode(body))
+
+	refused := listKeys("gzip;q=0")
+	assert.Equal(t, "", refused.Header().Get("Content-Encoding"))
+	assert.DeepEqual(t, want, decode(refused.Body.Bytes()))
+
+	// The requests are recorded in the remote signer metrics.
+	assert.Equal(t, listingsBefore+3, testutil.ToFloat64(listKeysRequestsTotal))
+}
+
+func TestGzipHandler_EmptyResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		wantCode int
+	}{
+		{
+			name:     "no content",
+			handler:  func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) },
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "implicit ok",
+			handler:  func(http.ResponseWriter, *http.Request) {},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "empty write",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				_, err := w.Write(nil)
+				require.NoError(t, err)
+			},
+			wantCode: http.StatusAccepted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			GzipHandler(tt.handler).ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, "", rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, 0, rec.Body.Len())
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package gateway
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"google.golang.org/grpc/status"
+)
+
+// ListPublicKeysPath is the path of the endpoint listing the validating public keys.
+const ListPublicKeysPath = "/accounts/v2/remote/accounts"
+
+const octetStreamContentType = "application/octet-stream"
+
+// PublicKeysOctetStreamHandler serves list public keys requests which accept an
+// application/octet-stream response with the 48-byte public keys concatenated in
+// the response body. Every other request, including list requests accepting JSON,
+// is passed on to the wrapped gateway handler.
+func PublicKeysOctetStreamHandler(h http.Handler, client pb.RemoteSignerClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != ListPublicKeysPath || !acceptsOctetStream(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		resp, err := client.ListValidatingPublicKeys(r.Context(), &empty.Empty{})
+		if err != nil {
+			st := status.Convert(err)
+			http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+			return
+		}
+		body := make([]byte, 0, len(resp.ValidatingPublicKeys)*48)
+		for _, key := range resp.ValidatingPublicKeys {
+			body = append(body, key...)
+		}
+		w.Header().Set("Content-Type", octetStreamContentType)
+		if _, err := w.Write(body); err != nil {
+			log.WithError(err).Error("Could not write public keys response")
+		}
+	})
+}
+
+// acceptsOctetStream returns true when application/octet-stream is listed in
+// the Accept header of the request and not explicitly refused with a zero
+// quality value.
+func acceptsOctetStream(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || mediaType != octetStreamContentType {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// WithSignatureOctetStream negotiates the marshaler of sign responses, such that
+// sign requests which accept an application/octet-stream response receive the raw
+// 96-byte signature as the response body. JSON remains the default marshaler, and
+// failed sign responses and errors are always marshaled as JSON.
+func WithSignatureOctetStream() runtime.ServeMuxOption {
+	return runtime.WithMarshalerOption(octetStreamContentType, &signatureMarshaler{
+		JSONPb: runtime.JSONPb{OrigName: true},
+	})
+}
+
+// signatureMarshaler marshals successful sign responses as their raw signature,
+// and every other message as JSON.
+type signatureMarshaler struct {
+	runtime.JSONPb
+}
+
+// Marshal returns the signature of a successful sign response.
+func (m *signatureMarshaler) Marshal(v interface{}) ([]byte, error) {
+	if resp, ok := signedResponse(v); ok {
+		return resp.Signature, nil
+	}
+	return m.JSONPb.Marshal(v)
+}
+
+// ContentTypeFromMessage returns the content type of the marshaled message.
+func (m *signatureMarshaler) ContentTypeFromMessage(v interface{}) string {
+	if _, ok := signedResponse(v); ok {
+		return octetStreamContentType
+	}
+	return m.JSONPb.ContentType()
+}
+
+// signedResponse returns the sign response holding a signature, if v is one.
+func signedResponse(v interface{}) (*pb.SignResponse, bool) {
+	resp, ok := v.(*pb.SignResponse)
+	if !ok || resp.Status != pb.SignResponse_SUCCEEDED || len(resp.Signature) == 0 {
+		return nil, false
+	}
+	return resp, true
+}
@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestPublicKeysOctetStreamHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	keys := [][]byte{
+		bytesutil.PadTo([]byte("key1"), 48),
+		bytesutil.PadTo([]byte("key2"), 48),
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterRemoteSignerServer(server, &listingRemoteSigner{keys: keys})
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer server.Stop()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		},
+	))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	mux := runtime.NewServeMux()
+	require.NoError(t, pb.RegisterRemoteSignerHandler(ctx, mux, conn))
+	h := PublicKeysOctetStreamHandler(mux, pb.NewRemoteSignerClient(conn))
+
+	listKeys := func(accept string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, ListPublicKeysPath, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		h.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec
+	}
+
+	// JSON remains the default.
+	rec := listKeys("")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	resp := &pb.ListPublicKeysResponse{}
+	require.NoError(t, (&runtime.JSONPb{OrigName: true}).Unmarshal(rec.Body.Bytes(), resp))
+	assert.DeepEqual(t, keys, resp.ValidatingPublicKeys)
+
+	rec = listKeys("text/html, application/octet-stream;q=0.9")
+	assert.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	assert.DeepEqual(t, append(append([]byte{}, keys[0]...), keys[1]...), rec.Body.Bytes())
+
+	// A zero quality value refuses the media type.
+	rec = listKeys("application/json, application/octet-stream;q=0")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
+
+type signingRemoteSigner struct {
+	pb.RemoteSignerClient
+	resp *pb.SignResponse
+}
+
+func (s *signingRemoteSigner) Sign(_ context.Context, _ *pb.SignRequest, _ ...grpc.CallOption) (*pb.SignResponse, error) {
+	return s.resp, nil
+}
+
+func TestWithSignatureOctetStream(t *testing.T) {
+	signature := bytesutil.PadTo([]byte("signature"), 96)
+	signer := &signingRemoteSigner{
+		resp: &pb.SignResponse{Signature: signature, Status: pb.SignResponse_SUCCEEDED},
+	}
+	mux := runtime.NewServeMux(WithSignatureOctetStream())
+	require.NoError(t, pb.RegisterRemoteSignerHandlerClient(context.Background(), mux, signer))
+
+	sign := func(accept string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, SignPath, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec
+	}
+
+	// JSON remains the default.
+	rec := sign("")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	resp := &pb.SignResponse{}
+	require.NoError(t, (&runtime.JSONPb{OrigName: true}).Unmarshal(rec.Body.Bytes(), resp))
+	assert.DeepEqual(t, signature, resp.Signature)
+	rec = sign("application/json")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	rec = sign("application/octet-stream")
+	assert.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	assert.DeepEqual(t, signature, rec.Body.Bytes())
+
+	// Responses without a signature are still marshaled as JSON.
+	signer.resp = &pb.SignResponse{Status: pb.SignResponse_DENIED}
+	rec = sign("application/octet-stream")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	resp = &pb.SignResponse{}
+	require.NoError(t, (&runtime.JSONPb{OrigName: true}).Unmarshal(rec.Body.Bytes(), resp))
+	assert.Equal(t, pb.SignResponse_DENIED, resp.Status)
+}
@@ -0,0 +1,31 @@
+package gateway
+
+import (
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// WithServerReflection registers the gRPC server reflection service alongside the
+// remote signer in RegisterRemoteSignerServer, so that tools such as grpcurl can
+// discover the RemoteSigner service and its methods. It is meant for debugging and
+// is best left off in production deployments.
+func WithServerReflection() RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.serverReflection = true
+	}
+}
+
+// RegisterRemoteSignerServer registers the remote signer implementation on the gRPC
+// server, serving the same implementation as RegisterRemoteSignerHandlerServer does
+// over HTTP. Server reflection is only registered with WithServerReflection.
+func RegisterRemoteSignerServer(s *grpc.Server, srv pb.RemoteSignerServer, opts ...RegisterOption) {
+	cfg := &registerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	pb.RegisterRemoteSignerServer(s, srv)
+	if cfg.serverReflection {
+		reflection.Register(s)
+	}
+}
@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRegisterRemoteSignerServer_Reflection(t *testing.T) {
+	listServices := func(t *testing.T, opts ...RegisterOption) ([]string, error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		lis := bufconn.Listen(1024 * 1024)
+		server := grpc.NewServer()
+		RegisterRemoteSignerServer(server, &listingRemoteSigner{}, opts...)
+		go func() {
+			if err := server.Serve(lis); err != nil {
+				t.Log(err)
+			}
+		}()
+		defer server.Stop()
+		conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+			func(context.Context, string) (net.Conn, error) {
+				return lis.Dial()
+			},
+		))
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, conn.Close())
+		}()
+
+		stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		require.NoError(t, err)
+		req := &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_ListServices{}}
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		var services []string
+		for _, service := range resp.GetListServicesResponse().Service {
+			services = append(services, service.Name)
+		}
+		return services, nil
+	}
+
+	services, err := listServices(t, WithServerReflection())
+	require.NoError(t, err)
+	found := false
+	for _, service := range services {
+		if service == "ethereum.validator.accounts.v2.RemoteSigner" {
+			found = true
+		}
+	}
+	assert.Equal(t, true, found, "Expected the RemoteSigner service to be listed, received %v", services)
+
+	// Reflection is off by default.
+	_, err = listServices(t)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// RegisterOption configures the handlers registered by RegisterVersionedRemoteSignerHandler.
+type RegisterOption func(*registerConfig)
+
+type registerConfig struct {
+	logSignRequests  bool
+	allowedOrigins   []string
+	signRootEndpoint bool
+	serverReflection bool
+	signRateLimit    float64
+	signRateBurst    int64
+	signTimeout      time.Duration
+}
+
+// WithSignRequestLogging logs every sign request for auditing, with the requesting
+// public key, the type of the object being signed and a truncated signing root.
+// The object itself and the signature are never logged.
+func WithSignRequestLogging() RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.logSignRequests = true
+	}
+}
+
+// signLoggingClient logs the sign requests forwarded to the wrapped client.
+type signLoggingClient struct {
+	pb.RemoteSignerClient
+}
+
+// Sign logs the request before forwarding it to the remote signer.
+func (c *signLoggingClient) Sign(ctx context.Context, in *pb.SignRequest, opts ...grpc.CallOption) (*pb.SignResponse, error) {
+	log.WithFields(logrus.Fields{
+		"publicKey":   fmt.Sprintf("%#x", in.PublicKey),
+		"objectType":  signObjectType(in),
+		"signingRoot": fmt.Sprintf("%#x", bytesutil.Trunc(in.SigningRoot)),
+	}).Info("Received sign request")
+	return c.RemoteSignerClient.Sign(ctx, in, opts...)
+}
+
+// signObjectType names the type of the beacon chain object of a sign request.
+func signObjectType(req *pb.SignRequest) string {
+	switch req.Object.(type) {
+	case *pb.SignRequest_Block:
+		return "block"
+	case *pb.SignRequest_AttestationData:
+		return "attestation_data"
+	case *pb.SignRequest_AggregateAttestationAndProof:
+		return "aggregate_attestation_and_proof"
+	case *pb.SignRequest_Exit:
+		return "exit"
+	case *pb.SignRequest_Slot:
+		return "slot"
+	case *pb.SignRequest_Epoch:
+		return "epoch"
+	default:
+		return "none"
+	}
+}
@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestWithSignRequestLogging(t *testing.T) {
+	hook := logTest.NewGlobal()
+	pubKey := bytesutil.PadTo([]byte("pubkey"), 48)
+	signingRoot := bytesutil.PadTo([]byte("signing root"), 32)
+	parentRoot := bytesutil.PadTo([]byte("parent root"), 32)
+
+	signer := &countingRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, signer, WithSignRequestLogging(),
+	))
+
+	params := url.Values{}
+	params.Set("public_key", base64.StdEncoding.EncodeToString(pubKey))
+	params.Set("signing_root", base64.StdEncoding.EncodeToString(signingRoot))
+	params.Set("block.slot", "7")
+	params.Set("block.parent_root", base64.StdEncoding.EncodeToString(parentRoot))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, SignPath+"?"+params.Encode(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 1, signer.signs)
+
+	assert.LogsContain(t, hook, "Received sign request")
+	assert.LogsContain(t, hook, fmt.Sprintf("%#x", pubKey))
+	assert.LogsContain(t, hook, "objectType=block")
+	assert.LogsContain(t, hook, fmt.Sprintf("%#x", bytesutil.Trunc(signingRoot)))
+	assert.LogsDoNotContain(t, hook, fmt.Sprintf("%#x", signingRoot))
+	assert.LogsDoNotContain(t, hook, fmt.Sprintf("%x", parentRoot))
+}
+
+func TestSignRequestLogging_OptIn(t *testing.T) {
+	hook := logTest.NewGlobal()
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, &countingRemoteSigner{},
+	))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, SignPath+"?slot=1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.LogsDoNotContain(t, hook, "Received sign request")
+}
@@ -0,0 +1,122 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	signRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "remote_signer_gateway",
+			Name:      "sign_requests_total",
+			Help:      "The number of sign requests received, by type of the object to sign",
+		},
+		[]string{
+			"type",
+		},
+	)
+	signFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "remote_signer_gateway",
+			Name:      "sign_failures_total",
+			Help:      "The number of failed sign requests, by type of the object to sign and error code or response status",
+		},
+		[]string{
+			"type",
+			"code",
+		},
+	)
+	signLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "remote_signer_gateway",
+			Name:      "sign_latency_seconds",
+			Help:      "The latency of sign requests forwarded to the remote signer",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		},
+		[]string{
+			"type",
+		},
+	)
+	listKeysRequestsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "remote_signer_gateway",
+			Name:      "list_keys_requests_total",
+			Help:      "The number of list validating public keys requests received",
+		},
+	)
+	listKeysFailuresTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "remote_signer_gateway",
+			Name:      "list_keys_failures_total",
+			Help:      "The number of failed list validating public keys requests",
+		},
+	)
+)
+
+// signRequestsByKeyTotal counts the sign requests received by the gateway per
+// requesting public key, to help detect a misbehaving or compromised key. As a
+// cardinality guard, keys are not used as labels directly but hashed into one of
+// 256 buckets, labeled by the first byte of the SHA-256 hash of the public key in
+// hex. The number of series is therefore bounded regardless of the number of keys.
+var signRequestsByKeyTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "remote_signer_gateway",
+		Name:      "sign_requests_by_key_total",
+		Help:      "The number of sign requests received, by bucket of the hashed requesting public key",
+	},
+	[]string{
+		"key_bucket",
+	},
+)
+
+// signMetricsClient records metrics of the sign and list keys requests forwarded
+// to the wrapped client.
+type signMetricsClient struct {
+	pb.RemoteSignerClient
+}
+
+// Sign counts the request and records its latency and failures while forwarding
+// it to the remote signer.
+func (c *signMetricsClient) Sign(ctx context.Context, in *pb.SignRequest, opts ...grpc.CallOption) (*pb.SignResponse, error) {
+	objType := signObjectType(in)
+	signRequestsTotal.WithLabelValues(objType).Inc()
+	signRequestsByKeyTotal.WithLabelValues(publicKeyBucket(in.PublicKey)).Inc()
+	start := time.Now()
+	resp, err := c.RemoteSignerClient.Sign(ctx, in, opts...)
+	signLatency.WithLabelValues(objType).Observe(time.Since(start).Seconds())
+	if err != nil {
+		signFailuresTotal.WithLabelValues(objType, status.Code(err).String()).Inc()
+		return nil, err
+	}
+	if resp.Status == pb.SignResponse_DENIED || resp.Status == pb.SignResponse_FAILED {
+		signFailuresTotal.WithLabelValues(objType, resp.Status.String()).Inc()
+	}
+	return resp, nil
+}
+
+// ListValidatingPublicKeys counts the request and its failures while forwarding it
+// to the remote signer.
+func (c *signMetricsClient) ListValidatingPublicKeys(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*pb.ListPublicKeysResponse, error) {
+	listKeysRequestsTotal.Inc()
+	resp, err := c.RemoteSignerClient.ListValidatingPublicKeys(ctx, in, opts...)
+	if err != nil {
+		listKeysFailuresTotal.Inc()
+	}
+	return resp, err
+}
+
+// publicKeyBucket returns the metrics bucket of a public key.
+func publicKeyBucket(pubKey []byte) string {
+	h := hashutil.Hash(pubKey)
+	return fmt.Sprintf("%02x", h[0])
+}
@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSignRequestsByKeyTotal(t *testing.T) {
+	pubKey := bytesutil.PadTo([]byte("metrics pubkey"), 48)
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, &countingRemoteSigner{},
+	))
+
+	counter := signRequestsByKeyTotal.WithLabelValues(publicKeyBucket(pubKey))
+	before := testutil.ToFloat64(counter)
+	params := url.Values{}
+	params.Set("public_key", base64.StdEncoding.EncodeToString(pubKey))
+	params.Set("slot", "1")
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, SignPath+"?"+params.Encode(), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+	assert.Equal(t, before+2, testutil.ToFloat64(counter))
+}
+
+func TestPublicKeyBucket(t *testing.T) {
+	buckets := make(map[string]bool)
+	for i := 0; i < 1024; i++ {
+		bucket := publicKeyBucket(bytesutil.PadTo(bytesutil.Bytes8(uint64(i)), 48))
+		assert.Equal(t, 2, len(bucket))
+		buckets[bucket] = true
+	}
+	assert.Equal(t, true, len(buckets) <= 256, "Expected at most 256 buckets")
+	assert.Equal(t, publicKeyBucket([]byte("key")), publicKeyBucket([]byte("key")))
+}
+
+type deniedRemoteSigner struct {
+	pb.RemoteSignerClient
+}
+
+func (deniedRemoteSigner) Sign(_ context.Context, _ *pb.SignRequest, _ ...grpc.CallOption) (*pb.SignResponse, error) {
+	return &pb.SignResponse{Status: pb.SignResponse_DENIED}, nil
+}
+
+func (deniedRemoteSigner) ListValidatingPublicKeys(_ context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*pb.ListPublicKeysResponse, error) {
+	return nil, status.Error(codes.Unavailable, "unavailable")
+}
+
+func TestSignMetrics(t *testing.T) {
+	serve := func(mux *runtime.ServeMux, method, path string) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	}
+	signs := signRequestsTotal.WithLabelValues("epoch")
+	denied := signFailuresTotal.WithLabelValues("epoch", pb.SignResponse_DENIED.String())
+	listings := listKeysRequestsTotal
+	listFailures := listKeysFailuresTotal
+	signsBefore, deniedBefore := testutil.ToFloat64(signs), testutil.ToFloat64(denied)
+	listingsBefore, listFailuresBefore := testutil.ToFloat64(listings), testutil.ToFloat64(listFailures)
+
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, &countingRemoteSigner{},
+	))
+	serve(mux, http.MethodPost, SignPath+"?epoch=1")
+	serve(mux, http.MethodGet, ListPublicKeysPath)
+	assert.Equal(t, signsBefore+1, testutil.ToFloat64(signs))
+	assert.Equal(t, deniedBefore, testutil.ToFloat64(denied))
+	assert.Equal(t, listingsBefore+1, testutil.ToFloat64(listings))
+	assert.Equal(t, listFailuresBefore, testutil.ToFloat64(listFailures))
+
+	mux = runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, deniedRemoteSigner{},
+	))
+	serve(mux, http.MethodPost, SignPath+"?epoch=1")
+	serve(mux, http.MethodGet, ListPublicKeysPath)
+	assert.Equal(t, signsBefore+2, testutil.ToFloat64(signs))
+	assert.Equal(t, deniedBefore+1, testutil.ToFloat64(denied))
+	assert.Equal(t, listingsBefore+2, testutil.ToFloat64(listings))
+	assert.Equal(t, listFailuresBefore+1, testutil.ToFloat64(listFailures))
+}
@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/kevinms/leakybucket-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithSignRateLimit limits the rate of sign requests accepted from each client to
+// requestsPerSecond, allowing bursts of up to burst requests. Clients are told apart
+// by their IP address, as the gateway does not authenticate the tokens of their
+// authorization header, which a client could otherwise change to evade the limit.
+// Requests over the limit are rejected with a resource exhausted error. Sign
+// requests are not rate limited unless this option is given. The buckets are
+// released once the context given to the registration is done.
+func WithSignRateLimit(requestsPerSecond float64, burst int64) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.signRateLimit = requestsPerSecond
+		cfg.signRateBurst = burst
+	}
+}
+
+// newSignRateLimiter returns the collector holding a token bucket per client, or
+// nil when sign requests are not rate limited. Buckets are deleted once drained, so
+// that clients which stopped sending requests are not kept in memory, and the
+// collector is freed once the context is done.
+func newSignRateLimiter(ctx context.Context, cfg *registerConfig) *leakybucket.Collector {
+	if cfg.signRateLimit <= 0 || cfg.signRateBurst <= 0 {
+		return nil
+	}
+	limiter := leakybucket.NewCollector(cfg.signRateLimit, cfg.signRateBurst, true /* deleteEmptyBuckets */)
+	go func() {
+		<-ctx.Done()
+		limiter.Free()
+	}()
+	return limiter
+}
+
+// signRateLimitHandlerFunc rejects the requests of clients which exceeded their
+// sign request rate, before they reach the given handler.
+func signRateLimitHandlerFunc(mux *runtime.ServeMux, limiter *leakybucket.Collector, handler runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		if limiter.Add(signRateLimitKey(req), 1) == 0 {
+			_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+			err := status.Error(codes.ResourceExhausted, "sign request rate limit exceeded")
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		handler(w, req, pathParams)
+	}
+}
+
+// signRateLimitKey identifies the client of a request for rate limiting by its
+// IP address.
+func signRateLimitKey(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
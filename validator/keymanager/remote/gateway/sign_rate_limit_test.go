@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestRegisterVersionedRemoteSignerHandlerClient_SignRateLimit(t *testing.T) {
+	sign := func(mux *runtime.ServeMux, remoteAddr, auth string) int {
+		req := httptest.NewRequest(http.MethodPost, RemoteSignerPath(DefaultAPIVersion, "sign")+"?slot=1", nil)
+		req.RemoteAddr = remoteAddr
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Sign requests are not rate limited by default.
+	signer := &countingRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, signer))
+	for i := 0; i < 10; i++ {
+		require.Equal(t, http.StatusOK, sign(mux, "192.0.2.1:1234", ""))
+	}
+	assert.Equal(t, 10, signer.signs)
+
+	signer = &countingRemoteSigner{}
+	mux = runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, signer, WithSignRateLimit(0.1, 2),
+	))
+	accepted, rejected := 0, 0
+	for i := 0; i < 10; i++ {
+		switch code := sign(mux, "192.0.2.1:1234", ""); code {
+		case http.StatusOK:
+			accepted++
+		case http.StatusTooManyRequests:
+			rejected++
+		default:
+			t.Fatalf("Unexpected status code %d", code)
+		}
+	}
+	assert.Equal(t, true, rejected > 0, "Expected requests over the rate limit to be rejected")
+	assert.Equal(t, accepted, signer.signs)
+
+	// Other clients have their own limit.
+	assert.Equal(t, http.StatusOK, sign(mux, "192.0.2.2:1234", ""))
+
+	// Changing the unauthenticated authorization header does not evade the limit.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusTooManyRequests, sign(mux, "192.0.2.1:1234", fmt.Sprintf("Bearer token-%d", i)))
+	}
+	assert.Equal(t, accepted, signer.signs)
+
+	// Only sign requests are rate limited.
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, RemoteSignerPath(DefaultAPIVersion, "accounts"), nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
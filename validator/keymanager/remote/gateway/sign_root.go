@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SignRootPath is the path of the endpoint signing a bare signing root.
+const SignRootPath = "/accounts/v2/remote/sign/root"
+
+// WithSignRootEndpoint enables the sign/root endpoint, which signs a 32-byte signing
+// root for a public key without the beacon chain object it was computed from.
+//
+// Warning: The remote signer can not check a bare root against its slashing
+// protection history, so any caller able to reach the endpoint can get slashable
+// messages signed. Only enable it for trusted callers which run their own slashing
+// protection. The endpoint is rejected with a permission denied error otherwise.
+func WithSignRootEndpoint() RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.signRootEndpoint = true
+	}
+}
+
+// signRootRequest forwards a sign request holding only a public key and a 32-byte
+// signing root to the remote signer.
+func signRootRequest(ctx context.Context, client pb.RemoteSignerClient, req *http.Request, md *runtime.ServerMetadata) (proto.Message, error) {
+	protoReq := &pb.SignRequest{}
+	if err := populateQueryParameters(protoReq, req); err != nil {
+		return nil, err
+	}
+	if len(protoReq.PublicKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing public key")
+	}
+	if len(protoReq.SigningRoot) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "signing root must be 32 bytes, received %d", len(protoReq.SigningRoot))
+	}
+	if protoReq.Object != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "root-only sign request must not hold a %s object", signObjectType(protoReq))
+	}
+	if len(protoReq.ForkVersion) != 0 {
+		return nil, status.Error(codes.InvalidArgument, "root-only sign request must not override the fork version")
+	}
+	return client.Sign(ctx, protoReq, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD))
+}
+
+// objectRequiredClient rejects sign requests which do not hold the beacon chain
+// object they sign, so that bare roots can not be signed through the sign endpoint
+// while the sign/root endpoint is disabled.
+type objectRequiredClient struct {
+	pb.RemoteSignerClient
+}
+
+// Sign forwards the request to the remote signer if it holds a beacon chain object.
+func (c *objectRequiredClient) Sign(ctx context.Context, in *pb.SignRequest, opts ...grpc.CallOption) (*pb.SignResponse, error) {
+	if in.Object == nil {
+		return nil, status.Error(codes.InvalidArgument, "sign request must hold the beacon chain object to sign")
+	}
+	return c.RemoteSignerClient.Sign(ctx, in, opts...)
+}
+
+// signRootDisabledHandlerFunc rejects requests to the sign/root endpoint while it
+// is not enabled with WithSignRootEndpoint.
+func signRootDisabledHandlerFunc(mux *runtime.ServeMux) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		err := status.Error(codes.PermissionDenied, "signing bare roots is disabled on this remote signer")
+		runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+	}
+}
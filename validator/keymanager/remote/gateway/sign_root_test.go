@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+)
+
+type recordingRemoteSigner struct {
+	pb.RemoteSignerClient
+	requests []*pb.SignRequest
+}
+
+func (r *recordingRemoteSigner) Sign(_ context.Context, in *pb.SignRequest, _ ...grpc.CallOption) (*pb.SignResponse, error) {
+	r.requests = append(r.requests, in)
+	return &pb.SignResponse{Status: pb.SignResponse_SUCCEEDED, Signature: []byte("sig")}, nil
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient_SignRoot(t *testing.T) {
+	root := bytesutil.PadTo([]byte("root"), 32)
+	signRootQuery := func(root []byte) string {
+		values := url.Values{}
+		values.Set("public_key", base64.StdEncoding.EncodeToString([]byte("key")))
+		values.Set("signing_root", base64.StdEncoding.EncodeToString(root))
+		return "?" + values.Encode()
+	}
+	query := signRootQuery(root)
+	signRoot := func(mux *runtime.ServeMux, query string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, SignRootPath+query, nil))
+		return rec.Code
+	}
+
+	// Root-only requests are rejected unless explicitly enabled.
+	signer := &recordingRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, signer))
+	assert.Equal(t, http.StatusForbidden, signRoot(mux, query))
+	assert.Equal(t, 0, len(signer.requests))
+
+	mux = runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, DefaultAPIVersion, signer, WithSignRootEndpoint(),
+	))
+	assert.Equal(t, http.StatusOK, signRoot(mux, query))
+	require.Equal(t, 1, len(signer.requests))
+	assert.DeepEqual(t, root, signer.requests[0].SigningRoot)
+
+	assert.Equal(t, http.StatusBadRequest, signRoot(mux, signRootQuery([]byte{1, 2})))
+	assert.Equal(t, http.StatusBadRequest, signRoot(mux, query+"&slot=5"))
+	assert.Equal(t, http.StatusBadRequest, signRoot(mux, query+"&fork_version="+url.QueryEscape(base64.StdEncoding.EncodeToString([]byte{1, 0, 0, 0}))))
+	assert.Equal(t, 1, len(signer.requests))
+
+	// Bare roots can not be signed through the sign endpoint while the sign/root
+	// endpoint is disabled either.
+	mux = runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, signer))
+	sign := func(query string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, SignPath+query, nil))
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusBadRequest, sign(query))
+	assert.Equal(t, 1, len(signer.requests))
+	assert.Equal(t, http.StatusOK, sign(query+"&slot=5"))
+	assert.Equal(t, 2, len(signer.requests))
+}
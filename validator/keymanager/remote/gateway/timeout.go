@@ -0,0 +1,42 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// SignPath is the path of the native sign endpoint of the remote signer gateway.
+const SignPath = "/accounts/v2/remote/sign"
+
+// WithSignTimeout bounds every sign request of the registered version, including
+// the sign/root endpoint, with the given timeout, so that a slow remote signer cannot
+// hold up a signing duty past its slot. The remote signer call fails with
+// codes.DeadlineExceeded once the timeout is exceeded. A non-positive timeout leaves
+// sign requests unbounded.
+func WithSignTimeout(timeout time.Duration) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.signTimeout = timeout
+	}
+}
+
+// SignTimeoutHandler bounds the sign requests served by the wrapped gateway handler
+// with the given timeout, so that a slow remote signer cannot hold up a signing duty
+// past its slot. The deadline propagates through the generated Sign handlers to the
+// remote signer call, which fails with codes.DeadlineExceeded once it is exceeded.
+// Only requests to SignPath are bounded; use WithSignTimeout for the versioned
+// handlers. A non-positive timeout leaves the handler unchanged.
+func SignTimeoutHandler(h http.Handler, timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != SignPath {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
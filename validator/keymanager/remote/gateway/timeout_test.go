@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type slowRemoteSigner struct {
+	pb.UnimplementedRemoteSignerServer
+	delay time.Duration
+}
+
+func (s *slowRemoteSigner) Sign(_ context.Context, _ *pb.SignRequest) (*pb.SignResponse, error) {
+	time.Sleep(s.delay)
+	return &pb.SignResponse{Status: pb.SignResponse_SUCCEEDED}, nil
+}
+
+func TestSignTimeoutHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterRemoteSignerServer(server, &slowRemoteSigner{delay: 500 * time.Millisecond})
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer server.Stop()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		},
+	))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+
+	mux := runtime.NewServeMux()
+	require.NoError(t, pb.RegisterRemoteSignerHandler(ctx, mux, conn))
+
+	sign := func(h http.Handler) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, SignPath, nil)
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	start := time.Now()
+	assert.Equal(t, http.StatusGatewayTimeout, sign(SignTimeoutHandler(mux, 50*time.Millisecond)))
+	assert.Equal(t, true, time.Since(start) < 500*time.Millisecond, "Expected sign request to time out early")
+
+	// Without a timeout the request waits for the remote signer.
+	assert.Equal(t, http.StatusOK, sign(SignTimeoutHandler(mux, 0)))
+}
+
+// delayedRemoteSigner succeeds sign requests after a delay, unless their context
+// is done first.
+type delayedRemoteSigner struct {
+	pb.RemoteSignerClient
+	delay time.Duration
+}
+
+func (s delayedRemoteSigner) Sign(ctx context.Context, _ *pb.SignRequest, _ ...grpc.CallOption) (*pb.SignResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	case <-time.After(s.delay):
+		return &pb.SignResponse{Status: pb.SignResponse_SUCCEEDED}, nil
+	}
+}
+
+func TestWithSignTimeout(t *testing.T) {
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(
+		context.Background(), mux, "v3", delayedRemoteSigner{delay: 500 * time.Millisecond},
+		WithSignTimeout(50*time.Millisecond), WithSignRootEndpoint(),
+	))
+
+	sign := func(path string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		return rec.Code
+	}
+	params := url.Values{}
+	params.Set("public_key", base64.StdEncoding.EncodeToString(make([]byte, 48)))
+	params.Set("slot", "1")
+	assert.Equal(t, http.StatusGatewayTimeout, sign(RemoteSignerPath("v3", "sign")+"?"+params.Encode()))
+
+	params = url.Values{}
+	params.Set("public_key", base64.StdEncoding.EncodeToString(make([]byte, 48)))
+	params.Set("signing_root", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	assert.Equal(t, http.StatusGatewayTimeout, sign(RemoteSignerPath("v3", "sign/root")+"?"+params.Encode()))
+}
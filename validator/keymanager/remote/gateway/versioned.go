@@ -0,0 +1,238 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/utilities"
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultAPIVersion is the version prefix of the remote signer API served by
+// the generated handlers, as in /accounts/v2/remote/sign.
+const DefaultAPIVersion = "v2"
+
+// remoteSignerRequest issues the gRPC call of a remote signer endpoint for an
+// incoming HTTP request.
+type remoteSignerRequest func(ctx context.Context, client pb.RemoteSignerClient, req *http.Request, md *runtime.ServerMetadata) (proto.Message, error)
+
+// remoteSignerRoute is an endpoint of the remote signer API, relative to the
+// /accounts/{version}/remote prefix.
+type remoteSignerRoute struct {
+	method  string
+	suffix  string
+	request remoteSignerRequest
+	// filterFields projects the response to the fields listed in the fields
+	// query parameter, when present.
+	filterFields bool
+	// bypassesSlashingProtection marks routes which are rejected unless enabled
+	// with WithSignRootEndpoint.
+	bypassesSlashingProtection bool
+	// signs marks routes issuing sign requests, which are rate limited with
+	// WithSignRateLimit.
+	signs bool
+}
+
+// remoteSignerRoutes mirrors the HTTP rules of the RemoteSigner service.
+var remoteSignerRoutes = []remoteSignerRoute{
+	{
+		method:       http.MethodGet,
+		suffix:       "accounts",
+		request:      listAccountsRequest,
+		filterFields: true,
+	},
+	{
+		method: http.MethodPost,
+		suffix: "sign",
+		request: func(ctx context.Context, client pb.RemoteSignerClient, req *http.Request, md *runtime.ServerMetadata) (proto.Message, error) {
+			protoReq := &pb.SignRequest{}
+			if err := populateQueryParameters(protoReq, req); err != nil {
+				return nil, err
+			}
+			if n := len(protoReq.ForkVersion); n != 0 && n != 4 {
+				return nil, status.Errorf(codes.InvalidArgument, "fork version must be 4 bytes, received %d", n)
+			}
+			return client.Sign(ctx, protoReq, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD))
+		},
+		signs: true,
+	},
+	{
+		method:                     http.MethodPost,
+		suffix:                     "sign/root",
+		request:                    signRootRequest,
+		bypassesSlashingProtection: true,
+		signs:                      true,
+	},
+	{
+		method: http.MethodPost,
+		suffix: "reload",
+		request: func(ctx context.Context, client pb.RemoteSignerClient, _ *http.Request, md *runtime.ServerMetadata) (proto.Message, error) {
+			return client.ReloadKeystores(ctx, &empty.Empty{}, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD))
+		},
+	},
+	{
+		method: http.MethodGet,
+		suffix: "signtypes",
+		request: func(ctx context.Context, client pb.RemoteSignerClient, _ *http.Request, md *runtime.ServerMetadata) (proto.Message, error) {
+			return client.ListSupportedSigningTypes(ctx, &empty.Empty{}, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD))
+		},
+	},
+}
+
+// listAccountsRequest lists the validating public keys of the remote signer, grouped
+// by their on-chain status and filtered to the given status when the request has a
+// status query parameter.
+func listAccountsRequest(ctx context.Context, client pb.RemoteSignerClient, req *http.Request, md *runtime.ServerMetadata) (proto.Message, error) {
+	if req.URL.Query().Get("status") == "" {
+		return client.ListValidatingPublicKeys(ctx, &empty.Empty{}, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD))
+	}
+	protoReq := &pb.ListAccountsByStatusRequest{}
+	if err := populateQueryParameters(protoReq, req); err != nil {
+		return nil, err
+	}
+	return client.ListValidatingAccountsByStatus(ctx, protoReq, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD))
+}
+
+// RemoteSignerPath returns the path of a remote signer endpoint for the given
+// API version, such as /accounts/v3/remote/sign for version "v3" and suffix "sign".
+func RemoteSignerPath(version, suffix string) string {
+	return fmt.Sprintf("/accounts/%s/remote/%s", version, suffix)
+}
+
+// RegisterVersionedRemoteSignerHandler registers the http handlers for service
+// RemoteSigner to "mux" under the /accounts/{version}/remote path prefix, forwarding
+// requests to the grpc endpoint over "conn". Registering several versions on the
+// same mux serves them side by side, e.g. both /accounts/v2/remote/sign and
+// /accounts/v3/remote/sign. The generated RegisterRemoteSignerHandler serves the same
+// paths as registering DefaultAPIVersion, and must not be combined with it on one mux,
+// but it does not support the fields query parameter.
+func RegisterVersionedRemoteSignerHandler(
+	ctx context.Context,
+	mux *runtime.ServeMux,
+	version string,
+	conn *grpc.ClientConn,
+	opts ...RegisterOption,
+) error {
+	return RegisterVersionedRemoteSignerHandlerClient(ctx, mux, version, pb.NewRemoteSignerClient(conn), opts...)
+}
+
+// RegisterVersionedRemoteSignerHandlerClient is the same as RegisterVersionedRemoteSignerHandler,
+// but forwards requests to the given implementation of "RemoteSignerClient".
+func RegisterVersionedRemoteSignerHandlerClient(
+	ctx context.Context,
+	mux *runtime.ServeMux,
+	version string,
+	client pb.RemoteSignerClient,
+	opts ...RegisterOption,
+) error {
+	if version == "" || strings.Contains(version, "/") {
+		return fmt.Errorf("invalid remote signer API version %q", version)
+	}
+	cfg := &registerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	client = wrapRemoteSignerClient(client, cfg)
+	c := newCors(cfg.allowedOrigins)
+	limiter := newSignRateLimiter(ctx, cfg)
+	for _, route := range remoteSignerRoutes {
+		pattern, err := remoteSignerPattern(version, route.suffix)
+		if err != nil {
+			return errors.Wrapf(err, "could not build pattern for %s", RemoteSignerPath(version, route.suffix))
+		}
+		handler := remoteSignerHandlerFunc(mux, client, route, cfg)
+		if route.bypassesSlashingProtection && !cfg.signRootEndpoint {
+			handler = signRootDisabledHandlerFunc(mux)
+		}
+		if route.signs && limiter != nil {
+			handler = signRateLimitHandlerFunc(mux, limiter, handler)
+		}
+		if c != nil {
+			handler = corsHandlerFunc(c, handler)
+			mux.Handle(http.MethodOptions, pattern, corsPreflightHandlerFunc(c))
+		}
+		mux.Handle(route.method, pattern, handler)
+	}
+	return nil
+}
+
+// wrapRemoteSignerClient wraps the client with the sign metrics, the sign request
+// logging and the beacon chain object check, as configured. Every registration of
+// the remote signer handlers goes through it, so that sign requests are handled
+// the same way whichever handlers serve them.
+func wrapRemoteSignerClient(client pb.RemoteSignerClient, cfg *registerConfig) pb.RemoteSignerClient {
+	client = &signMetricsClient{RemoteSignerClient: client}
+	if cfg.logSignRequests {
+		client = &signLoggingClient{RemoteSignerClient: client}
+	}
+	if !cfg.signRootEndpoint {
+		client = &objectRequiredClient{RemoteSignerClient: client}
+	}
+	return client
+}
+
+// remoteSignerPattern builds the pattern matching /accounts/{version}/remote/{suffix},
+// in the same form as the patterns generated for the RemoteSigner service.
+func remoteSignerPattern(version, suffix string) (runtime.Pattern, error) {
+	pool := append([]string{"accounts", version, "remote"}, strings.Split(suffix, "/")...)
+	ops := make([]int, 0, 2*len(pool))
+	for i := range pool {
+		ops = append(ops, int(utilities.OpLitPush), i)
+	}
+	return runtime.NewPattern(1, ops, pool, "", runtime.AssumeColonVerbOpt(true))
+}
+
+// remoteSignerHandlerFunc returns a mux handler issuing the request of the route, in
+// the same way as the handlers generated for the RemoteSigner service. Sign requests
+// are bounded by the timeout of WithSignTimeout, if any.
+func remoteSignerHandlerFunc(mux *runtime.ServeMux, client pb.RemoteSignerClient, route remoteSignerRoute, cfg *registerConfig) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		ctx := req.Context()
+		if route.signs && cfg.signTimeout > 0 {
+			var cancelTimeout context.CancelFunc
+			ctx, cancelTimeout = context.WithTimeout(ctx, cfg.signTimeout)
+			defer cancelTimeout()
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		rctx, err := runtime.AnnotateContext(ctx, mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		var md runtime.ServerMetadata
+		resp, err := route.request(rctx, client, req, &md)
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		if fields := requestedFields(req); route.filterFields && fields != nil {
+			outboundMarshaler = &fieldsMarshaler{Marshaler: outboundMarshaler, fields: fields}
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	}
+}
+
+// populateQueryParameters fills the request message from the query parameters,
+// as the generated handlers do.
+func populateQueryParameters(msg proto.Message, req *http.Request) error {
+	if err := req.ParseForm(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	filter := &utilities.DoubleArray{Encoding: map[string]int{}}
+	if err := runtime.PopulateQueryParameters(msg, req.Form, filter); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+)
+
+type countingRemoteSigner struct {
+	pb.RemoteSignerClient
+	signs    int
+	listings int
+}
+
+func (c *countingRemoteSigner) Sign(_ context.Context, _ *pb.SignRequest, _ ...grpc.CallOption) (*pb.SignResponse, error) {
+	c.signs++
+	return &pb.SignResponse{Status: pb.SignResponse_SUCCEEDED}, nil
+}
+
+func (c *countingRemoteSigner) ListValidatingPublicKeys(_ context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*pb.ListPublicKeysResponse, error) {
+	c.listings++
+	return &pb.ListPublicKeysResponse{}, nil
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient(t *testing.T) {
+	ctx := context.Background()
+	v2, v3 := &countingRemoteSigner{}, &countingRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(ctx, mux, DefaultAPIVersion, v2))
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(ctx, mux, "v3", v3))
+
+	serve := func(method, path string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec.Code
+	}
+	// The v2 paths are unchanged.
+	assert.Equal(t, SignPath, RemoteSignerPath(DefaultAPIVersion, "sign"))
+	assert.Equal(t, ListPublicKeysPath, RemoteSignerPath(DefaultAPIVersion, "accounts"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, SignPath+"?slot=1"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, ListPublicKeysPath))
+	assert.Equal(t, 1, v2.signs)
+	assert.Equal(t, 1, v2.listings)
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/accounts/v3/remote/sign?slot=1"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/accounts/v3/remote/accounts"))
+	assert.Equal(t, 1, v3.signs)
+	assert.Equal(t, 1, v3.listings)
+	assert.Equal(t, 1, v2.signs, "Expected v3 requests to not reach the v2 signer")
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPost, "/accounts/v4/remote/sign"))
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient_InvalidVersion(t *testing.T) {
+	mux := runtime.NewServeMux()
+	for _, version := range []string{"", "v3/beta"} {
+		err := RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, version, &countingRemoteSigner{})
+		assert.ErrorContains(t, "invalid remote signer API version", err)
+	}
+}
+
+type reloadingRemoteSigner struct {
+	pb.RemoteSignerClient
+	reloads int
+}
+
+func (r *reloadingRemoteSigner) ReloadKeystores(_ context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*pb.ReloadKeystoresResponse, error) {
+	r.reloads++
+	return &pb.ReloadKeystoresResponse{Added: 2, Removed: 1}, nil
+}
+
+type signTypesRemoteSigner struct {
+	pb.RemoteSignerClient
+}
+
+func (signTypesRemoteSigner) ListSupportedSigningTypes(_ context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*pb.ListSupportedSigningTypesResponse, error) {
+	return &pb.ListSupportedSigningTypesResponse{
+		SigningTypes: []pb.ListSupportedSigningTypesResponse_SigningType{
+			pb.ListSupportedSigningTypesResponse_BLOCK,
+			pb.ListSupportedSigningTypesResponse_ATTESTATION_DATA,
+		},
+	}, nil
+}
+
+type statusRemoteSigner struct {
+	countingRemoteSigner
+	statusRequests []*pb.ListAccountsByStatusRequest
+}
+
+func (s *statusRemoteSigner) ListValidatingAccountsByStatus(_ context.Context, in *pb.ListAccountsByStatusRequest, _ ...grpc.CallOption) (*pb.ListAccountsByStatusResponse, error) {
+	s.statusRequests = append(s.statusRequests, in)
+	return &pb.ListAccountsByStatusResponse{
+		Accounts: []*pb.AccountsWithStatus{{Status: in.Status, ValidatingPublicKeys: [][]byte{[]byte("key")}}},
+	}, nil
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient_AccountsStatus(t *testing.T) {
+	signer := &statusRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, signer))
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// Without a status, the public keys are listed as before.
+	rec := serve(ListPublicKeysPath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, signer.listings)
+	assert.Equal(t, 0, len(signer.statusRequests))
+
+	rec = serve(ListPublicKeysPath + "?status=ACTIVE")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, signer.listings)
+	require.Equal(t, 1, len(signer.statusRequests))
+	assert.Equal(t, ethpb.ValidatorStatus_ACTIVE, signer.statusRequests[0].Status)
+	resp := &pb.ListAccountsByStatusResponse{}
+	require.NoError(t, (&runtime.JSONPb{}).Unmarshal(rec.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Accounts))
+	assert.Equal(t, ethpb.ValidatorStatus_ACTIVE, resp.Accounts[0].Status)
+
+	assert.Equal(t, http.StatusBadRequest, serve(ListPublicKeysPath+"?status=NOT_A_STATUS").Code)
+	assert.Equal(t, http.StatusNotFound, serve(RemoteSignerPath(DefaultAPIVersion, "accounts/status")).Code)
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient_SignTypes(t *testing.T) {
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, signTypesRemoteSigner{}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, RemoteSignerPath(DefaultAPIVersion, "signtypes"), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	resp := &pb.ListSupportedSigningTypesResponse{}
+	require.NoError(t, (&runtime.JSONPb{}).Unmarshal(rec.Body.Bytes(), resp))
+	assert.DeepEqual(t, []pb.ListSupportedSigningTypesResponse_SigningType{
+		pb.ListSupportedSigningTypesResponse_BLOCK,
+		pb.ListSupportedSigningTypesResponse_ATTESTATION_DATA,
+	}, resp.SigningTypes)
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient_Reload(t *testing.T) {
+	signer := &reloadingRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, signer))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, RemoteSignerPath(DefaultAPIVersion, "reload"), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, signer.reloads)
+	resp := &pb.ReloadKeystoresResponse{}
+	require.NoError(t, (&runtime.JSONPb{}).Unmarshal(rec.Body.Bytes(), resp))
+	assert.Equal(t, uint64(2), resp.Added)
+	assert.Equal(t, uint64(1), resp.Removed)
+}
+
+func TestRegisterVersionedRemoteSignerHandlerClient_SignForkVersion(t *testing.T) {
+	signer := &recordingRemoteSigner{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, RegisterVersionedRemoteSignerHandlerClient(context.Background(), mux, DefaultAPIVersion, signer))
+	sign := func(forkVersion []byte) int {
+		values := url.Values{}
+		values.Set("public_key", base64.StdEncoding.EncodeToString([]byte("key")))
+		values.Set("epoch", "10")
+		if forkVersion != nil {
+			values.Set("fork_version", base64.StdEncoding.EncodeToString(forkVersion))
+		}
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, RemoteSignerPath(DefaultAPIVersion, "sign")+"?"+values.Encode(), nil)
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, sign(nil))
+	require.Equal(t, http.StatusOK, sign([]byte{1, 0, 0, 0}))
+	require.Equal(t, 2, len(signer.requests))
+	assert.Equal(t, 0, len(signer.requests[0].ForkVersion))
+	assert.DeepEqual(t, []byte{1, 0, 0, 0}, signer.requests[1].ForkVersion)
+	assert.Equal(t, uint64(10), signer.requests[1].GetEpoch())
+
+	assert.Equal(t, http.StatusBadRequest, sign([]byte{1, 0}))
+	assert.Equal(t, 2, len(signer.requests))
+}
@@ -0,0 +1,178 @@
+// Package gateway defines HTTP handlers and helpers for serving the remote signer
+// API over HTTP JSON, on top of the handlers generated for the RemoteSigner service.
+package gateway
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Web3SignerPath is the path prefix of the Web3Signer signing endpoint,
+// which is followed by the hex encoded public key used for signing.
+const Web3SignerPath = "/api/v1/eth2/sign/"
+
+// maxWeb3SignerBodySize bounds the body of Web3Signer signing requests, which
+// leaves room for the JSON encoding of a full beacon block.
+const maxWeb3SignerBodySize = 2 << 20
+
+// Signing object types defined by the Web3Signer API.
+const (
+	web3SignerBlock             = "BLOCK"
+	web3SignerAttestation       = "ATTESTATION"
+	web3SignerAggregateAndProof = "AGGREGATE_AND_PROOF"
+	web3SignerVoluntaryExit     = "VOLUNTARY_EXIT"
+	web3SignerRandaoReveal      = "RANDAO_REVEAL"
+	web3SignerAggregationSlot   = "AGGREGATION_SLOT"
+)
+
+// web3SignerRequest is the body of a Web3Signer signing request. The typed
+// beacon chain objects are expected in the JSON encoding of their protobuf
+// definitions, as served by the rest of the gateway.
+type web3SignerRequest struct {
+	Type              string          `json:"type"`
+	SigningRoot       string          `json:"signingRoot"`
+	Block             json.RawMessage `json:"block,omitempty"`
+	Attestation       json.RawMessage `json:"attestation,omitempty"`
+	AggregateAndProof json.RawMessage `json:"aggregate_and_proof,omitempty"`
+	VoluntaryExit     json.RawMessage `json:"voluntary_exit,omitempty"`
+	RandaoReveal      *struct {
+		Epoch string `json:"epoch"`
+	} `json:"randao_reveal,omitempty"`
+	AggregationSlot *struct {
+		Slot string `json:"slot"`
+	} `json:"aggregation_slot,omitempty"`
+}
+
+// Web3SignerHandler returns an HTTP handler serving the Web3Signer
+// POST /api/v1/eth2/sign/{identifier} endpoint. Requests are mapped onto
+// a SignRequest for the public key given as identifier and forwarded to the
+// remote signer, and the resulting signature is written to the response body
+// as a 0x prefixed hex string. The native sign endpoint is unaffected.
+func Web3SignerHandler(client pb.RemoteSignerClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req.Body = http.MaxBytesReader(w, req.Body, maxWeb3SignerBodySize)
+		signReq, err := web3SignerToSignRequest(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, err := client.Sign(req.Context(), signReq)
+		if err != nil {
+			st := status.Convert(err)
+			http.Error(w, st.Message(), web3SignerHTTPStatus(st.Code()))
+			return
+		}
+		switch resp.Status {
+		case pb.SignResponse_SUCCEEDED:
+		case pb.SignResponse_DENIED:
+			http.Error(w, "signing request was denied", http.StatusPreconditionFailed)
+			return
+		default:
+			http.Error(w, "signing request failed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		if _, err := w.Write([]byte("0x" + hex.EncodeToString(resp.Signature))); err != nil {
+			log.WithError(err).Error("Could not write Web3Signer response")
+		}
+	})
+}
+
+// web3SignerToSignRequest builds a SignRequest from a Web3Signer signing request.
+func web3SignerToSignRequest(req *http.Request) (*pb.SignRequest, error) {
+	identifier := strings.TrimPrefix(req.URL.Path, Web3SignerPath)
+	pubKey, err := decodeHex(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identifier: %v", err)
+	}
+	if len(pubKey) != 48 {
+		return nil, fmt.Errorf("invalid identifier: public key must be 48 bytes, received %d", len(pubKey))
+	}
+	body := &web3SignerRequest{}
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		return nil, fmt.Errorf("invalid request body: %v", err)
+	}
+	signingRoot, err := decodeHex(body.SigningRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing root: %v", err)
+	}
+	signReq := &pb.SignRequest{
+		PublicKey:   pubKey,
+		SigningRoot: signingRoot,
+	}
+	unmarshaler := &runtime.JSONPb{OrigName: true}
+	switch body.Type {
+	case web3SignerBlock:
+		block := &ethpb.BeaconBlock{}
+		if err := unmarshaler.Unmarshal(body.Block, block); err != nil {
+			return nil, fmt.Errorf("invalid block: %v", err)
+		}
+		signReq.Object = &pb.SignRequest_Block{Block: block}
+	case web3SignerAttestation:
+		data := &ethpb.AttestationData{}
+		if err := unmarshaler.Unmarshal(body.Attestation, data); err != nil {
+			return nil, fmt.Errorf("invalid attestation: %v", err)
+		}
+		signReq.Object = &pb.SignRequest_AttestationData{AttestationData: data}
+	case web3SignerAggregateAndProof:
+		aggregate := &ethpb.AggregateAttestationAndProof{}
+		if err := unmarshaler.Unmarshal(body.AggregateAndProof, aggregate); err != nil {
+			return nil, fmt.Errorf("invalid aggregate and proof: %v", err)
+		}
+		signReq.Object = &pb.SignRequest_AggregateAttestationAndProof{AggregateAttestationAndProof: aggregate}
+	case web3SignerVoluntaryExit:
+		exit := &ethpb.VoluntaryExit{}
+		if err := unmarshaler.Unmarshal(body.VoluntaryExit, exit); err != nil {
+			return nil, fmt.Errorf("invalid voluntary exit: %v", err)
+		}
+		signReq.Object = &pb.SignRequest_Exit{Exit: exit}
+	case web3SignerRandaoReveal:
+		if body.RandaoReveal == nil {
+			return nil, fmt.Errorf("missing randao reveal")
+		}
+		epoch, err := strconv.ParseUint(body.RandaoReveal.Epoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid randao reveal epoch: %v", err)
+		}
+		signReq.Object = &pb.SignRequest_Epoch{Epoch: epoch}
+	case web3SignerAggregationSlot:
+		if body.AggregationSlot == nil {
+			return nil, fmt.Errorf("missing aggregation slot")
+		}
+		slot, err := strconv.ParseUint(body.AggregationSlot.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid aggregation slot: %v", err)
+		}
+		signReq.Object = &pb.SignRequest_Slot{Slot: slot}
+	default:
+		return nil, fmt.Errorf("unsupported signing type %q", body.Type)
+	}
+	return signReq, nil
+}
+
+// web3SignerHTTPStatus maps a gRPC error code to the HTTP status used by the
+// Web3Signer API, which reports slashable requests as a failed precondition.
+func web3SignerHTTPStatus(code codes.Code) int {
+	if code == codes.FailedPrecondition {
+		return http.StatusPreconditionFailed
+	}
+	return runtime.HTTPStatusFromCode(code)
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
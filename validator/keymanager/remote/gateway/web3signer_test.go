@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2_gateway"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRemoteSigner struct {
+	pb.RemoteSignerClient
+	lastReq *pb.SignRequest
+	resp    *pb.SignResponse
+	err     error
+}
+
+func (f *fakeRemoteSigner) Sign(_ context.Context, in *pb.SignRequest, _ ...grpc.CallOption) (*pb.SignResponse, error) {
+	f.lastReq = in
+	return f.resp, f.err
+}
+
+func TestWeb3SignerHandler(t *testing.T) {
+	pubKey := make([]byte, 48)
+	pubKey[0] = 1
+	signingRoot := make([]byte, 32)
+	signingRoot[0] = 2
+	sig := make([]byte, 96)
+	sig[0] = 3
+	path := Web3SignerPath + "0x" + hex.EncodeToString(pubKey)
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		resp     *pb.SignResponse
+		err      error
+		wantCode int
+		check    func(t *testing.T, req *pb.SignRequest)
+	}{
+		{
+			name:     "aggregation slot",
+			body:     `{"type":"AGGREGATION_SLOT","signingRoot":"0x` + hex.EncodeToString(signingRoot) + `","aggregation_slot":{"slot":"12"}}`,
+			resp:     &pb.SignResponse{Signature: sig, Status: pb.SignResponse_SUCCEEDED},
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, req *pb.SignRequest) {
+				assert.DeepEqual(t, pubKey, req.PublicKey)
+				assert.DeepEqual(t, signingRoot, req.SigningRoot)
+				assert.Equal(t, uint64(12), req.GetSlot())
+			},
+		},
+		{
+			name:     "randao reveal",
+			body:     `{"type":"RANDAO_REVEAL","signingRoot":"0x` + hex.EncodeToString(signingRoot) + `","randao_reveal":{"epoch":"3"}}`,
+			resp:     &pb.SignResponse{Signature: sig, Status: pb.SignResponse_SUCCEEDED},
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, req *pb.SignRequest) {
+				assert.Equal(t, uint64(3), req.GetEpoch())
+			},
+		},
+		{
+			name:     "voluntary exit",
+			body:     `{"type":"VOLUNTARY_EXIT","signingRoot":"0x` + hex.EncodeToString(signingRoot) + `","voluntary_exit":{"epoch":"5","validator_index":"7"}}`,
+			resp:     &pb.SignResponse{Signature: sig, Status: pb.SignResponse_SUCCEEDED},
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, req *pb.SignRequest) {
+				assert.Equal(t, uint64(5), req.GetExit().Epoch)
+				assert.Equal(t, uint64(7), req.GetExit().ValidatorIndex)
+			},
+		},
+		{
+			name:     "unsupported type",
+			body:     `{"type":"SYNC_COMMITTEE_MESSAGE","signingRoot":"0x` + hex.EncodeToString(signingRoot) + `"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "short public key",
+			path:     Web3SignerPath + "0x" + hex.EncodeToString(pubKey[:32]),
+			body:     `{"type":"AGGREGATION_SLOT","signingRoot":"0x` + hex.EncodeToString(signingRoot) + `","aggregation_slot":{"slot":"12"}}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "oversized body",
+			body:     `{"type":"AGGREGATION_SLOT","signingRoot":"0x` + strings.Repeat("00", maxWeb3SignerBodySize) + `"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "slashable request",
+			body:     `{"type":"AGGREGATION_SLOT","signingRoot":"0x` + hex.EncodeToString(signingRoot) + `","aggregation_slot":{"slot":"12"}}`,
+			err:      status.Error(codes.FailedPrecondition, "slashable"),
+			wantCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:     "denied",
+			body:     `{"type":"AGGREGATION_SLOT","signingRoot":"0x` + hex.EncodeToString(signingRoot) + `","aggregation_slot":{"slot":"12"}}`,
+			resp:     &pb.SignResponse{Status: pb.SignResponse_DENIED},
+			wantCode: http.StatusPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := &fakeRemoteSigner{resp: tt.resp, err: tt.err}
+			rec := httptest.NewRecorder()
+			reqPath := path
+			if tt.path != "" {
+				reqPath = tt.path
+			}
+			req := httptest.NewRequest(http.MethodPost, reqPath, strings.NewReader(tt.body))
+			Web3SignerHandler(signer).ServeHTTP(rec, req)
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			body, err := ioutil.ReadAll(rec.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "0x"+hex.EncodeToString(sig), string(body))
+			tt.check(t, signer.lastReq)
+		})
+	}
+}
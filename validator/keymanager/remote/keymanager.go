@@ -13,6 +13,7 @@ import (
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/logrusorgru/aurora"
 	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -208,6 +209,48 @@ func (k *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][48]byte,
 	return pubKeys, nil
 }
 
+// FetchValidatingPublicKeysByStatus fetches the public keys managed by the remote signer
+// grouped by their on-chain status, as reported by the beacon node attached to the signer.
+// Only keys with the given status are returned, unless it is UNKNOWN_STATUS.
+func (k *Keymanager) FetchValidatingPublicKeysByStatus(
+	ctx context.Context, validatorStatus ethpb.ValidatorStatus,
+) (map[ethpb.ValidatorStatus][][48]byte, error) {
+	resp, err := k.client.ListValidatingAccountsByStatus(ctx, &validatorpb.ListAccountsByStatusRequest{
+		Status: validatorStatus,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list accounts by status from remote server")
+	}
+	pubKeysByStatus := make(map[ethpb.ValidatorStatus][][48]byte, len(resp.Accounts))
+	for _, accounts := range resp.Accounts {
+		for _, key := range accounts.ValidatingPublicKeys {
+			pubKeysByStatus[accounts.Status] = append(pubKeysByStatus[accounts.Status], bytesutil.ToBytes48(key))
+		}
+	}
+	return pubKeysByStatus, nil
+}
+
+// ReloadKeystores asks the remote signer to rescan its keystores, returning the number
+// of validating public keys which were added and removed. Newly loaded keys are
+// returned by FetchValidatingPublicKeys right away.
+func (k *Keymanager) ReloadKeystores(ctx context.Context) (added, removed uint64, err error) {
+	resp, err := k.client.ReloadKeystores(ctx, &ptypes.Empty{})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not reload keystores of remote server")
+	}
+	return resp.Added, resp.Removed, nil
+}
+
+// ListSupportedSigningTypes asks the remote signer for the types of data its
+// implementation supports signing.
+func (k *Keymanager) ListSupportedSigningTypes(ctx context.Context) ([]validatorpb.ListSupportedSigningTypesResponse_SigningType, error) {
+	resp, err := k.client.ListSupportedSigningTypes(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list supported signing types of remote server")
+	}
+	return resp.SigningTypes, nil
+}
+
 // FetchAllValidatingPublicKeys fetches the list of all public keys, including disabled ones.
 func (dr *Keymanager) FetchAllValidatingPublicKeys(ctx context.Context) ([][48]byte, error) {
 	return dr.FetchValidatingPublicKeys(ctx)
@@ -217,8 +260,55 @@ func (dr *Keymanager) FetchAllValidatingPublicKeys(ctx context.Context) ([][48]b
 func (k *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
 	resp, err := k.client.Sign(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, signError(err)
 	}
+	return signatureFromResponse(resp)
+}
+
+// SignStream signs the provided requests over a single gRPC stream to the remote
+// signer, avoiding a round trip per request when signing for many keys. The
+// signatures are returned in the order of the requests. Streaming is not supported
+// by the gRPC gateway, so this requires a direct gRPC connection to the signer.
+func (k *Keymanager) SignStream(ctx context.Context, reqs []*validatorpb.SignRequest) ([]bls.Signature, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := k.client.SignStream(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open sign stream to remote server")
+	}
+
+	// Requests are sent in the background so the remote signer can
+	// start signing while the responses are being received.
+	sendErr := make(chan error, 1)
+	go func() {
+		for _, req := range reqs {
+			if err := stream.Send(req); err != nil {
+				sendErr <- err
+				return
+			}
+		}
+		sendErr <- stream.CloseSend()
+	}()
+
+	sigs := make([]bls.Signature, len(reqs))
+	for i := range reqs {
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, signError(err)
+		}
+		sigs[i], err = signatureFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := <-sendErr; err != nil {
+		return nil, errors.Wrap(err, "could not send sign requests to remote server")
+	}
+	return sigs, nil
+}
+
+// signatureFromResponse returns the signature from a remote signer response.
+func signatureFromResponse(resp *validatorpb.SignResponse) (bls.Signature, error) {
 	switch resp.Status {
 	case validatorpb.SignResponse_DENIED:
 		return nil, ErrSigningDenied
@@ -6,18 +6,26 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 var validClientCert = `-----BEGIN CERTIFICATE-----
@@ -261,6 +269,119 @@ func TestRemoteKeymanager_Sign(t *testing.T) {
 	assert.DeepEqual(t, sig.Marshal(), resp.Marshal())
 }
 
+func TestRemoteKeymanager_Sign_ErrorDetails(t *testing.T) {
+	tests := []struct {
+		reason  string
+		code    int
+		wantErr error
+	}{
+		{
+			reason:  ReasonUnknownPublicKey,
+			code:    http.StatusNotFound,
+			wantErr: ErrUnknownPublicKey,
+		},
+		{
+			reason:  ReasonMalformedSigningRoot,
+			code:    http.StatusBadRequest,
+			wantErr: ErrMalformedSigningRoot,
+		},
+		{
+			reason:  ReasonSlashableRequest,
+			code:    http.StatusBadRequest,
+			wantErr: ErrSlashableRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mock.NewMockRemoteSignerClient(ctrl)
+			k := &Keymanager{
+				client: m,
+			}
+			signErr := NewSignError(tt.reason, "could not sign")
+			m.EXPECT().Sign(
+				gomock.Any(), // ctx
+				gomock.Any(), // request
+			).Return(nil, signErr)
+			_, err := k.Sign(context.Background(), nil)
+			assert.Equal(t, true, errors.Is(err, tt.wantErr), "Expected %v, received %v", tt.wantErr, err)
+
+			// The details must survive translation through the gateway.
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/accounts/v2/remote/sign", nil)
+			runtime.HTTPError(context.Background(), runtime.NewServeMux(), &runtime.JSONPb{}, rec, req, signErr)
+			assert.Equal(t, tt.code, rec.Code)
+			assert.Equal(t, true, bytes.Contains(rec.Body.Bytes(), []byte(tt.reason)), "Expected reason %s in gateway response %s", tt.reason, rec.Body.String())
+		})
+	}
+}
+
+type streamingRemoteSigner struct {
+	validatorpb.UnimplementedRemoteSignerServer
+	secretKey bls.SecretKey
+}
+
+func (s *streamingRemoteSigner) SignStream(stream validatorpb.RemoteSigner_SignStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&validatorpb.SignResponse{
+			Status:    validatorpb.SignResponse_SUCCEEDED,
+			Signature: s.secretKey.Sign(req.SigningRoot).Marshal(),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func TestRemoteKeymanager_SignStream(t *testing.T) {
+	ctx := context.Background()
+	secretKey, err := bls.RandKey()
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	validatorpb.RegisterRemoteSignerServer(server, &streamingRemoteSigner{secretKey: secretKey})
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		},
+	))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	k := &Keymanager{
+		client: validatorpb.NewRemoteSignerClient(conn),
+	}
+
+	reqs := make([]*validatorpb.SignRequest, 100)
+	for i := range reqs {
+		reqs[i] = &validatorpb.SignRequest{
+			PublicKey:   secretKey.PublicKey().Marshal(),
+			SigningRoot: []byte(fmt.Sprintf("signing-root-%d", i)),
+		}
+	}
+	sigs, err := k.SignStream(ctx, reqs)
+	require.NoError(t, err)
+	require.Equal(t, len(reqs), len(sigs))
+	for i, sig := range sigs {
+		assert.Equal(t, true, sig.Verify(secretKey.PublicKey(), reqs[i].SigningRoot), "Signature %d does not match its request", i)
+	}
+}
+
 func TestRemoteKeymanager_FetchValidatingPublicKeys(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mock.NewMockRemoteSignerClient(ctrl)
@@ -309,6 +430,113 @@ func TestRemoteKeymanager_FetchValidatingPublicKeys(t *testing.T) {
 	assert.DeepEqual(t, pubKeys, rawKeys)
 }
 
+func TestRemoteKeymanager_FetchValidatingPublicKeysByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mock.NewMockRemoteSignerClient(ctrl)
+	k := &Keymanager{
+		client: m,
+	}
+
+	// Expect error handling to work.
+	m.EXPECT().ListValidatingAccountsByStatus(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(nil, errors.New("could not fetch keys"))
+	_, err := k.FetchValidatingPublicKeysByStatus(context.Background(), eth.ValidatorStatus_UNKNOWN_STATUS)
+	require.ErrorContains(t, "could not fetch keys", err)
+
+	activeKey := make([]byte, 48)
+	copy(activeKey, "active")
+	exitedKeys := make([][]byte, 2)
+	for i := 0; i < len(exitedKeys); i++ {
+		exitedKeys[i] = make([]byte, 48)
+		copy(exitedKeys[i], "exited"+strconv.Itoa(i))
+	}
+	m.EXPECT().ListValidatingAccountsByStatus(
+		gomock.Any(), // ctx
+		&validatorpb.ListAccountsByStatusRequest{Status: eth.ValidatorStatus_UNKNOWN_STATUS},
+	).Return(&validatorpb.ListAccountsByStatusResponse{
+		Accounts: []*validatorpb.AccountsWithStatus{
+			{Status: eth.ValidatorStatus_ACTIVE, ValidatingPublicKeys: [][]byte{activeKey}},
+			{Status: eth.ValidatorStatus_EXITED, ValidatingPublicKeys: exitedKeys},
+		},
+	}, nil /*err*/)
+	keys, err := k.FetchValidatingPublicKeysByStatus(context.Background(), eth.ValidatorStatus_UNKNOWN_STATUS)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+	require.Equal(t, 1, len(keys[eth.ValidatorStatus_ACTIVE]))
+	assert.DeepEqual(t, activeKey, keys[eth.ValidatorStatus_ACTIVE][0][:])
+	require.Equal(t, 2, len(keys[eth.ValidatorStatus_EXITED]))
+	for i, key := range keys[eth.ValidatorStatus_EXITED] {
+		assert.DeepEqual(t, exitedKeys[i], key[:])
+	}
+}
+
+func TestRemoteKeymanager_ReloadKeystores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mock.NewMockRemoteSignerClient(ctrl)
+	k := &Keymanager{
+		client: m,
+	}
+
+	// Expect error handling to work.
+	m.EXPECT().ReloadKeystores(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(nil, errors.New("could not reload"))
+	_, _, err := k.ReloadKeystores(context.Background())
+	require.ErrorContains(t, "could not reload", err)
+
+	newKey := make([]byte, 48)
+	copy(newKey, "new")
+	m.EXPECT().ReloadKeystores(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&validatorpb.ReloadKeystoresResponse{Added: 1, Removed: 2}, nil /*err*/)
+	m.EXPECT().ListValidatingPublicKeys(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&validatorpb.ListPublicKeysResponse{
+		ValidatingPublicKeys: [][]byte{newKey},
+	}, nil /*err*/)
+	added, removed, err := k.ReloadKeystores(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), added)
+	assert.Equal(t, uint64(2), removed)
+	keys, err := k.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+	assert.DeepEqual(t, newKey, keys[0][:])
+}
+
+func TestRemoteKeymanager_ListSupportedSigningTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mock.NewMockRemoteSignerClient(ctrl)
+	k := &Keymanager{
+		client: m,
+	}
+
+	// Expect error handling to work.
+	m.EXPECT().ListSupportedSigningTypes(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(nil, errors.New("could not list"))
+	_, err := k.ListSupportedSigningTypes(context.Background())
+	require.ErrorContains(t, "could not list", err)
+
+	want := []validatorpb.ListSupportedSigningTypesResponse_SigningType{
+		validatorpb.ListSupportedSigningTypesResponse_BLOCK,
+		validatorpb.ListSupportedSigningTypesResponse_ATTESTATION_DATA,
+	}
+	m.EXPECT().ListSupportedSigningTypes(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&validatorpb.ListSupportedSigningTypesResponse{SigningTypes: want}, nil /*err*/)
+	signingTypes, err := k.ListSupportedSigningTypes(context.Background())
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, signingTypes)
+}
+
 func TestUnmarshalOptionsFile_DefaultRequireTls(t *testing.T) {
 	optsWithoutTls := struct {
 		RemoteCertificate struct {